@@ -8,8 +8,14 @@ import (
 
 	"void-cutter/internal/audio"
 	"void-cutter/internal/config"
+	"void-cutter/internal/declip"
+	"void-cutter/internal/denoise"
+	"void-cutter/internal/dynamics"
+	"void-cutter/internal/filter"
+	"void-cutter/internal/hum"
 	"void-cutter/internal/loudness"
 	"void-cutter/internal/silence"
+	"void-cutter/pkg/detector"
 
 	"github.com/spf13/cobra"
 )
@@ -41,10 +47,81 @@ func init() {
 	// Add flags based on CLI specification
 	rootCmd.Flags().StringVarP(&cfg.OutputSuffix, "output-suffix", "s", cfg.OutputSuffix,
 		"Suffix to append to output file names")
+	rootCmd.Flags().StringVarP(&cfg.Preset, "preset", "p", cfg.Preset,
+		"Loudness delivery preset ("+strings.Join(loudness.PresetNames(), ", ")+")")
 	rootCmd.Flags().Float64VarP(&cfg.TargetLoudness, "target-loudness", "l", cfg.TargetLoudness,
 		"Target loudness value in LUFS")
+	rootCmd.Flags().Float64Var(&cfg.TruePeakCeiling, "true-peak-ceiling", cfg.TruePeakCeiling,
+		"Maximum true peak in dBTP (enforced with a preset or when given; otherwise only gains above 6 dB that would exceed it are limited)")
+	rootCmd.Flags().StringVar(&cfg.NormalizeMode, "normalize-mode", cfg.NormalizeMode,
+		"Loudness normalization mode: independent (per track), mix (balance tracks, normalize the summed program) or linked (one shared gain for all tracks)")
+	rootCmd.Flags().BoolVar(&cfg.SpeechGated, "speech-gated", cfg.SpeechGated,
+		"Measure loudness only where each track is active (above the silence threshold)")
+	rootCmd.Flags().StringArrayVar(&cfg.Gain, "gain", cfg.Gain,
+		"Gain offset on top of normalization: [file=]+1.5dB; repeatable")
+	rootCmd.Flags().StringArrayVar(&cfg.Target, "target", cfg.Target,
+		"Track-specific loudness target: [file=]-18; repeatable")
+	rootCmd.Flags().StringArrayVar(&cfg.SkipNormalize, "skip-normalize", cfg.SkipNormalize,
+		"Leave a file untouched by loudness normalization; repeatable")
+	rootCmd.Flags().BoolVar(&cfg.Declip, "declip", cfg.Declip,
+		"Reconstruct clipped peaks in the input before any processing")
+	rootCmd.Flags().IntVar(&cfg.ClipMinRun, "clip-min-run", cfg.ClipMinRun,
+		"Consecutive samples at a track's peak level that count as clipping")
+	rootCmd.Flags().StringArrayVar(&cfg.Filter, "filter", cfg.Filter,
+		"Filter tracks before analysis: [file=]hpf:80,lpf:12000,lowshelf:200:-3,highshelf:8000:2,peak:3000:2:1.4,notch:60,bandpass:1000 (type:freq[:gain][:q], or \"default\" for hpf:80); repeatable")
+	rootCmd.Flags().StringArrayVar(&cfg.Dehum, "dehum", cfg.Dehum,
+		"Remove mains hum detected in silence: [file=]fundamental:0,harmonics:8,q:30,prominence:10 (fundamental 0 detects 50/60 Hz, or \"default\"); repeatable")
+	rootCmd.Flags().StringArrayVar(&cfg.Denoise, "denoise", cfg.Denoise,
+		"Reduce noise using a profile learned from silence: [file=]reduction:12,oversubtraction:1.5,smoothing:0.5 (or \"default\"); repeatable")
+	rootCmd.Flags().StringArrayVar(&cfg.Gate, "gate", cfg.Gate,
+		"Gate tracks: [file=]threshold:-45,hysteresis:6,hold:150,range:20,ratio:0,attack:2,release:100,relative:0 (or \"default\"); repeatable")
+	rootCmd.Flags().StringArrayVar(&cfg.DeEsser, "deesser", cfg.DeEsser,
+		"De-ess tracks: [file=]threshold:-30,reduction:6,low:5000,high:9000,attack:1,release:60 (or \"default\"); repeatable")
+	rootCmd.Flags().StringArrayVar(&cfg.Compressor, "compressor", cfg.Compressor,
+		"Compress tracks: [file=]threshold:-20,ratio:3,knee:6,attack:10,release:150,makeup:0,sidechain-hpf:100 (or \"default\"); repeatable")
+	rootCmd.Flags().BoolVar(&cfg.Leveler, "leveler", cfg.Leveler,
+		"Ride each track's gain towards the target loudness during speech before normalization")
+	rootCmd.Flags().Float64Var(&cfg.LevelerMaxBoost, "leveler-max-boost", cfg.LevelerMaxBoost,
+		"Maximum leveler gain increase in dB")
+	rootCmd.Flags().Float64Var(&cfg.LevelerMaxCut, "leveler-max-cut", cfg.LevelerMaxCut,
+		"Maximum leveler gain reduction in dB")
+	rootCmd.Flags().Float64Var(&cfg.LevelerSpeed, "leveler-speed", cfg.LevelerSpeed,
+		"Leveler time constant in seconds (larger is slower)")
+	rootCmd.Flags().BoolVar(&cfg.PostCutCorrection, "post-cut-correction", cfg.PostCutCorrection,
+		"Re-measure loudness after cutting silence and correct it if outside the tolerance")
+	rootCmd.Flags().Float64Var(&cfg.PostCutTolerance, "post-cut-tolerance", cfg.PostCutTolerance,
+		"Allowed loudness deviation in LU before the post-cut correction applies gain")
+	rootCmd.Flags().StringVar(&cfg.DetectionMode, "detection-mode", cfg.DetectionMode,
+		"Silence detector: "+strings.Join(detector.Names(), ", ")+
+			" (rms: level only, adaptive: level above the noise floor, vad: level and speech-like spectrum)")
+	rootCmd.Flags().BoolVar(&cfg.SpeechBandDetection, "speech-band-detection", cfg.SpeechBandDetection,
+		"Measure silence detection energy within the speech band (150-4000 Hz) only; the output is not filtered")
+	rootCmd.Flags().StringVar(&cfg.DetectionChannels, "detection-channels", cfg.DetectionChannels,
+		"Channels for silence detection: mix (all channels together), any (active if any channel is), or channel numbers like 1,2")
+	rootCmd.Flags().BoolVar(&cfg.RefineBoundaries, "refine-boundaries", cfg.RefineBoundaries,
+		"Place silence boundaries on the exact frame where the level crosses the threshold and snap cuts to a common zero crossing")
+	rootCmd.Flags().IntVar(&cfg.SilenceQuorum, "silence-quorum", cfg.SilenceQuorum,
+		"Tracks that must be silent to cut: 0 for all, N for at least N, -N for all but N")
+	rootCmd.Flags().StringArrayVar(&cfg.ExcludeDetection, "exclude-from-detection", cfg.ExcludeDetection,
+		"Leave a file (music bed, ambience mic) out of the silence decision while still cutting it in sync; repeatable")
 	rootCmd.Flags().Float64VarP(&cfg.SilenceThreshold, "silence-threshold", "t", cfg.SilenceThreshold,
 		"Silence threshold in dBFS (-120 to 0)")
+	rootCmd.Flags().StringVar(&cfg.ThresholdRelativeTo, "threshold-relative-to", cfg.ThresholdRelativeTo,
+		"Set each track's silence threshold relative to its loudness: integrated or speech (replaces --silence-threshold)")
+	rootCmd.Flags().Float64Var(&cfg.ThresholdBelow, "threshold-below", cfg.ThresholdBelow,
+		"dB below the reference loudness for --threshold-relative-to")
+	rootCmd.Flags().BoolVar(&cfg.AutoThreshold, "auto-threshold", cfg.AutoThreshold,
+		"Shorthand for --detection-mode "+silence.ModeAdaptive)
+	rootCmd.Flags().Float64Var(&cfg.ThresholdMargin, "threshold-margin", cfg.ThresholdMargin,
+		"Margin above the noise floor in dB for the "+silence.ModeAdaptive+" detector")
+	rootCmd.Flags().Float64Var(&cfg.SilenceHysteresis, "silence-hysteresis", cfg.SilenceHysteresis,
+		"Silence is broken only this many dB above the threshold")
+	rootCmd.Flags().IntVar(&cfg.MinBurstDuration, "min-burst-duration", cfg.MinBurstDuration,
+		"Sound shorter than this (ms) does not break silence, e.g. breaths and clicks")
+	rootCmd.Flags().IntVar(&cfg.SilenceHold, "silence-hold", cfg.SilenceHold,
+		"Time (ms) a track stays active after its level drops")
+	rootCmd.Flags().IntVar(&cfg.SilenceRelease, "silence-release", cfg.SilenceRelease,
+		"Time (ms) after the hold over which the level falls to the silence threshold")
 	rootCmd.Flags().IntVarP(&cfg.MinSilenceDuration, "min-silence-duration", "m", cfg.MinSilenceDuration,
 		"Minimum silence duration in milliseconds")
 	rootCmd.Flags().IntVarP(&cfg.KeepSilenceDuration, "keep-silence-duration", "k", cfg.KeepSilenceDuration,
@@ -66,26 +143,143 @@ func runVoidCutter(cmd *cobra.Command, args []string) error {
 	// Get test mode flag
 	testMode, _ := cmd.Flags().GetBool("test-copy")
 
+	// Apply loudness delivery preset (explicitly given flags take precedence)
+	var preset *loudness.Preset
+	if cfg.Preset != "" {
+		var err error
+		preset, err = loudness.GetPreset(cfg.Preset)
+		if err != nil {
+			return fmt.Errorf("configuration validation failed: %w", err)
+		}
+
+		if !cmd.Flags().Changed("target-loudness") {
+			cfg.TargetLoudness = preset.TargetLUFS
+		}
+		if !cmd.Flags().Changed("true-peak-ceiling") {
+			cfg.TruePeakCeiling = preset.TruePeakCeiling
+		}
+	}
+
+	// --auto-threshold only selects the adaptive detector
+	if cfg.AutoThreshold {
+		if cmd.Flags().Changed("detection-mode") && cfg.DetectionMode != silence.ModeAdaptive {
+			return fmt.Errorf("configuration validation failed: --auto-threshold cannot be combined with --detection-mode %s",
+				cfg.DetectionMode)
+		}
+		cfg.DetectionMode = silence.ModeAdaptive
+	}
+
 	// Validate configuration
 	if err := cfg.Validate(); err != nil {
 		return fmt.Errorf("configuration validation failed: %w", err)
 	}
+	if err := loudness.ValidateTargetLoudness(cfg.TargetLoudness); err != nil {
+		return fmt.Errorf("configuration validation failed: %w", err)
+	}
+	if err := loudness.ValidateTruePeakCeiling(cfg.TruePeakCeiling); err != nil {
+		return fmt.Errorf("configuration validation failed: %w", err)
+	}
+	if _, err := detector.Lookup(cfg.DetectionMode); err != nil {
+		return fmt.Errorf("configuration validation failed: %w", err)
+	}
+	if cfg.NormalizeMode == loudness.ModeLinked && len(cfg.Target) > 0 {
+		return fmt.Errorf("configuration validation failed: --target cannot be combined with --normalize-mode %s (use --gain to offset tracks)",
+			loudness.ModeLinked)
+	}
+	if cfg.ThresholdRelativeTo != "" && cfg.DetectionMode == silence.ModeAdaptive {
+		return fmt.Errorf("configuration validation failed: a relative threshold cannot be combined with the %s detector",
+			silence.ModeAdaptive)
+	}
+	perChannel, detectionChannels, err := silence.ParseChannelSelection(cfg.DetectionChannels)
+	if err != nil {
+		return fmt.Errorf("configuration validation failed: %w", err)
+	}
 
 	// Validate input files exist and are WAV files
-	for _, file := range cfg.InputFiles {
-		if _, err := os.Stat(file); os.IsNotExist(err) {
-			return fmt.Errorf("input file not found: %s", file)
-		}
+	if err := validateInputFiles(cfg.InputFiles); err != nil {
+		return err
+	}
 
-		if !strings.HasSuffix(strings.ToLower(file), ".wav") {
-			return fmt.Errorf("input file must be a WAV file: %s", file)
-		}
+	excludedTracks, err := matchFileList("exclude-from-detection", cfg.ExcludeDetection, cfg.InputFiles)
+	if err != nil {
+		return fmt.Errorf("configuration validation failed: %w", err)
+	}
+
+	// Resolve per-track processing settings
+	normalizationOverrides, err := buildNormalizationOverrides(cfg.InputFiles)
+	if err != nil {
+		return fmt.Errorf("configuration validation failed: %w", err)
+	}
+
+	filterChains, err := buildFilterChains(cfg.InputFiles)
+	if err != nil {
+		return fmt.Errorf("configuration validation failed: %w", err)
+	}
+
+	humConfigs, err := buildHumConfigs(cfg.InputFiles)
+	if err != nil {
+		return fmt.Errorf("configuration validation failed: %w", err)
+	}
+
+	noiseReductionConfigs, err := buildNoiseReductionConfigs(cfg.InputFiles)
+	if err != nil {
+		return fmt.Errorf("configuration validation failed: %w", err)
+	}
+
+	gateConfigs, err := buildGateConfigs(cfg.InputFiles)
+	if err != nil {
+		return fmt.Errorf("configuration validation failed: %w", err)
+	}
+
+	deEsserConfigs, err := buildDeEsserConfigs(cfg.InputFiles)
+	if err != nil {
+		return fmt.Errorf("configuration validation failed: %w", err)
+	}
+
+	compressorConfigs, err := buildCompressorConfigs(cfg.InputFiles)
+	if err != nil {
+		return fmt.Errorf("configuration validation failed: %w", err)
 	}
 
 	fmt.Printf("void-cutter started with %d input files\n", len(cfg.InputFiles))
 	fmt.Printf("Configuration:\n")
+	if preset != nil {
+		fmt.Printf("  Preset: %s\n", preset.Description)
+	}
 	fmt.Printf("  Target Loudness: %.1f LUFS\n", cfg.TargetLoudness)
-	fmt.Printf("  Silence Threshold: %.1f dBFS\n", cfg.SilenceThreshold)
+	fmt.Printf("  True Peak Ceiling: %.1f dBTP\n", cfg.TruePeakCeiling)
+	fmt.Printf("  Normalize Mode: %s\n", cfg.NormalizeMode)
+	fmt.Printf("  Speech-Gated Measurement: %t\n", cfg.SpeechGated)
+	if cfg.Leveler {
+		fmt.Printf("  Leveler: max boost %.1f dB, max cut %.1f dB, speed %.1fs\n",
+			cfg.LevelerMaxBoost, cfg.LevelerMaxCut, cfg.LevelerSpeed)
+	}
+	if cfg.PostCutCorrection {
+		fmt.Printf("  Post-Cut Correction: ±%.1f LU\n", cfg.PostCutTolerance)
+	}
+	fmt.Printf("  Detection Mode: %s\n", cfg.DetectionMode)
+	if cfg.SpeechBandDetection {
+		fmt.Printf("  Detection Band: 150-4000 Hz\n")
+	}
+	if perChannel {
+		fmt.Printf("  Detection Channels: %s\n", cfg.DetectionChannels)
+	}
+	if cfg.RefineBoundaries {
+		fmt.Printf("  Boundaries: sample-accurate, snapped cuts\n")
+	}
+	if cfg.SilenceQuorum != 0 {
+		fmt.Printf("  Silence Quorum: %d\n", cfg.SilenceQuorum)
+	}
+	for _, file := range cfg.ExcludeDetection {
+		fmt.Printf("  Excluded From Detection: %s\n", file)
+	}
+	if cfg.ThresholdRelativeTo != "" {
+		fmt.Printf("  Silence Threshold: %.1f dB below %s loudness\n", cfg.ThresholdBelow, cfg.ThresholdRelativeTo)
+	} else if cfg.DetectionMode == silence.ModeAdaptive {
+		fmt.Printf("  Silence Threshold: auto (noise floor + %.1f dB)\n", cfg.ThresholdMargin)
+	} else {
+		fmt.Printf("  Silence Threshold: %.1f dBFS\n", cfg.SilenceThreshold)
+	}
 	fmt.Printf("  Min Silence Duration: %d ms\n", cfg.MinSilenceDuration)
 	fmt.Printf("  Keep Silence Duration: %d ms\n", cfg.KeepSilenceDuration)
 	fmt.Printf("  Output Suffix: %s\n", cfg.OutputSuffix)
@@ -146,6 +340,159 @@ func runVoidCutter(cmd *cobra.Command, args []string) error {
 	}
 
 	// Normal processing mode
+	silenceConfig := silence.SilenceDetectionConfig{
+		Config: detector.Config{
+			ThresholdDBFS: cfg.SilenceThreshold,
+			RelativeTo:    cfg.ThresholdRelativeTo,
+			RelativeDB:    cfg.ThresholdBelow,
+			MarginDB:      cfg.ThresholdMargin,
+			HysteresisDB:  cfg.SilenceHysteresis,
+			MinBurstMs:    cfg.MinBurstDuration,
+			HoldMs:        cfg.SilenceHold,
+			ReleaseMs:     cfg.SilenceRelease,
+			ChunkSizeMs:   10, // 10ms chunks for analysis
+			VAD:           detector.DefaultVADConfig(),
+			SpeechBand:    cfg.SpeechBandDetection,
+			PerChannel:    perChannel,
+			Channels:      detectionChannels,
+		},
+		Mode:             cfg.DetectionMode,
+		MinDurationMs:    cfg.MinSilenceDuration,
+		RefineBoundaries: cfg.RefineBoundaries,
+		Quorum:           cfg.SilenceQuorum,
+		Excluded:         excludedTracks,
+	}
+	if err := silenceConfig.ValidateChannels(audioFiles[0].Channels); err != nil {
+		return fmt.Errorf("configuration validation failed: %w", err)
+	}
+	if err := silenceConfig.ValidateQuorum(len(audioFiles)); err != nil {
+		return fmt.Errorf("configuration validation failed: %w", err)
+	}
+
+	// The ceiling is enforced only when a preset or the flag asks for it;
+	// otherwise only large boosts that would clip are limited
+	normConfig := loudness.NormalizationConfig{
+		TargetLUFS:      cfg.TargetLoudness,
+		TruePeakCeiling: cfg.TruePeakCeiling,
+		LimitPeaks:      preset != nil || cmd.Flags().Changed("true-peak-ceiling"),
+		Mode:            cfg.NormalizeMode,
+		SpeechGated:     cfg.SpeechGated,
+		Silence:         silenceConfig,
+		Overrides:       normalizationOverrides,
+	}
+
+	// Input clipping is checked on the untouched recordings
+	fmt.Println("\nChecking input clipping...")
+	clippingReports, err := declip.DetectClippingMultipleAudio(audioFiles, cfg.ClipMinRun)
+	if err != nil {
+		return fmt.Errorf("failed to check clipping: %w", err)
+	}
+	declip.PrintClippingSummary(clippingReports)
+
+	if cfg.Declip {
+		fmt.Println("\nDeclipping tracks...")
+		declipResults, err := declip.DeclipMultipleAudio(audioFiles, clippingReports)
+		if err != nil {
+			return fmt.Errorf("failed to declip audio: %w", err)
+		}
+
+		declip.PrintDeclipSummary(audioFiles, declipResults)
+	}
+
+	// Filters run first so that rumble and EQ changes are reflected in every measurement
+	if len(cfg.Filter) > 0 {
+		fmt.Println("\nFiltering tracks...")
+		filterResults, err := filter.FilterMultipleAudio(audioFiles, filterChains)
+		if err != nil {
+			return fmt.Errorf("failed to filter audio: %w", err)
+		}
+
+		filter.PrintFilterSummary(audioFiles, filterResults)
+	}
+
+	// Hum and noise profiles are learned from the silence common to all tracks
+	var profileRegions []silence.SilenceRegion
+	if len(cfg.Dehum) > 0 || len(cfg.Denoise) > 0 {
+		fmt.Println("\nFinding silence for noise analysis...")
+		profileConfig := silenceConfig
+		profileConfig.Quorum, profileConfig.Excluded = 0, nil // Noise is learned only where every track is silent
+		detection, err := silence.DetectCommonSilence(audioFiles, profileConfig)
+		if err != nil {
+			return fmt.Errorf("failed to detect silence: %w", err)
+		}
+		profileRegions = detection.CommonSilenceRegions
+	}
+
+	if len(cfg.Dehum) > 0 {
+		fmt.Println("\nRemoving hum...")
+		humResults, err := hum.RemoveHumMultipleAudio(audioFiles, profileRegions, humConfigs)
+		if err != nil {
+			return fmt.Errorf("failed to remove hum: %w", err)
+		}
+
+		hum.PrintHumSummary(audioFiles, humResults)
+	}
+
+	// Noise reduction runs before any gain so the noise floor is not raised first
+	if len(cfg.Denoise) > 0 {
+		fmt.Println("\nReducing noise...")
+		noiseResults, err := denoise.ReduceNoiseMultipleAudio(audioFiles, profileRegions, noiseReductionConfigs)
+		if err != nil {
+			return fmt.Errorf("failed to reduce noise: %w", err)
+		}
+
+		denoise.PrintNoiseReductionSummary(audioFiles, noiseResults)
+	}
+
+	// Per-track dynamics
+	if len(cfg.Gate) > 0 {
+		fmt.Println("\nGating tracks...")
+		gateResults, err := dynamics.GateMultipleAudio(audioFiles, gateConfigs)
+		if err != nil {
+			return fmt.Errorf("failed to gate audio: %w", err)
+		}
+
+		dynamics.PrintGateSummary(audioFiles, gateResults)
+	}
+
+	if len(cfg.DeEsser) > 0 {
+		fmt.Println("\nDe-essing tracks...")
+		deEsserResults, err := dynamics.DeEssMultipleAudio(audioFiles, deEsserConfigs)
+		if err != nil {
+			return fmt.Errorf("failed to de-ess audio: %w", err)
+		}
+
+		dynamics.PrintDeEsserSummary(audioFiles, deEsserResults)
+	}
+
+	if len(cfg.Compressor) > 0 {
+		fmt.Println("\nCompressing tracks...")
+		compressionResults, err := dynamics.CompressMultipleAudio(audioFiles, compressorConfigs)
+		if err != nil {
+			return fmt.Errorf("failed to compress audio: %w", err)
+		}
+
+		dynamics.PrintCompressionSummary(audioFiles, compressionResults)
+	}
+
+	// Speech leveling rides gain before the final normalization
+	if cfg.Leveler {
+		levelerConfig := loudness.DefaultLevelerConfig()
+		levelerConfig.TargetLUFS = cfg.TargetLoudness
+		levelerConfig.MaxBoostDB = cfg.LevelerMaxBoost
+		levelerConfig.MaxCutDB = cfg.LevelerMaxCut
+		levelerConfig.SpeedSec = cfg.LevelerSpeed
+		levelerConfig.Silence = silenceConfig
+
+		fmt.Println("\nLeveling speech...")
+		levelerResults, err := loudness.LevelMultipleAudio(audioFiles, levelerConfig)
+		if err != nil {
+			return fmt.Errorf("failed to level audio: %w", err)
+		}
+
+		loudness.PrintLevelerSummary(levelerResults, levelerConfig)
+	}
+
 	// Loudness measurement and normalization
 	fmt.Println("\nMeasuring loudness...")
 	var loudnessResults []*loudness.LoudnessResult
@@ -153,32 +500,51 @@ func runVoidCutter(cmd *cobra.Command, args []string) error {
 	for i, audioData := range audioFiles {
 		fmt.Printf("[%d/%d] Measuring: %s", i+1, len(audioFiles), audioData.Filename)
 
-		result, err := loudness.MeasureLoudness(audioData)
+		result, err := loudness.MeasureForNormalization(audioData, normConfig)
 		if err != nil {
 			return fmt.Errorf("failed to measure loudness for %s: %w", audioData.Filename, err)
 		}
 
 		loudnessResults = append(loudnessResults, result)
-		fmt.Printf(" ✓ (%.1f LUFS)\n", result.IntegratedLoudness)
+		if result.SpeechGated {
+			fmt.Printf(" ✓ (%.1f LUFS speech, %.0f%% active)\n", result.IntegratedLoudness, result.SpeechRatio*100)
+		} else {
+			fmt.Printf(" ✓ (%.1f LUFS)\n", result.IntegratedLoudness)
+		}
 	}
 
 	// Apply loudness normalization
-	fmt.Printf("\nApplying loudness normalization (target: %.1f LUFS)...\n", cfg.TargetLoudness)
-	normResults, err := loudness.NormalizeMultipleAudio(audioFiles, cfg.TargetLoudness)
-	if err != nil {
-		return fmt.Errorf("failed to normalize audio: %w", err)
-	}
 
-	// Print normalization summary
-	loudness.PrintNormalizationSummary(normResults)
+	switch cfg.NormalizeMode {
+	case loudness.ModeMix:
+		fmt.Printf("\nApplying mix-aware loudness normalization (target: %.1f LUFS)...\n", cfg.TargetLoudness)
+		mixResult, err := loudness.NormalizeMix(audioFiles, normConfig)
+		if err != nil {
+			return fmt.Errorf("failed to normalize audio: %w", err)
+		}
+
+		mixResult.Print()
+	case loudness.ModeLinked:
+		fmt.Printf("\nApplying linked loudness normalization (target: %.1f LUFS)...\n", cfg.TargetLoudness)
+		linkedResult, err := loudness.NormalizeLinked(audioFiles, normConfig)
+		if err != nil {
+			return fmt.Errorf("failed to normalize audio: %w", err)
+		}
+
+		linkedResult.Print()
+	default:
+		fmt.Printf("\nApplying loudness normalization (target: %.1f LUFS)...\n", cfg.TargetLoudness)
+		normResults, err := loudness.NormalizeMultipleAudio(audioFiles, normConfig)
+		if err != nil {
+			return fmt.Errorf("failed to normalize audio: %w", err)
+		}
+
+		// Print normalization summary
+		loudness.PrintNormalizationSummary(normResults)
+	}
 
 	// Detect common silence regions
 	fmt.Println("\nDetecting common silence regions...")
-	silenceConfig := silence.SilenceDetectionConfig{
-		ThresholdDBFS: cfg.SilenceThreshold,
-		MinDurationMs: cfg.MinSilenceDuration,
-		ChunkSizeMs:   10, // 10ms chunks for analysis
-	}
 
 	detectionResult, err := silence.DetectCommonSilence(audioFiles, silenceConfig)
 	if err != nil {
@@ -186,14 +552,22 @@ func runVoidCutter(cmd *cobra.Command, args []string) error {
 	}
 
 	detectionResult.Print()
+	if debugInfo {
+		detectionResult.PrintChannelLevels()
+	}
 
 	// Cut silence regions if any were found
 	if len(detectionResult.CommonSilenceRegions) > 0 {
 		fmt.Printf("\nCutting silence regions (keeping %d ms)...\n", cfg.KeepSilenceDuration)
 
+		cutRegions := detectionResult.CommonSilenceRegions
+		if cfg.RefineBoundaries {
+			cutRegions = silence.SnapCutPoints(audioFiles, cutRegions, cfg.KeepSilenceDuration, silenceConfig)
+		}
+
 		cuttingResults, err := silence.CutSilenceInMultipleFiles(
 			audioFiles,
-			detectionResult.CommonSilenceRegions,
+			cutRegions,
 			cfg.KeepSilenceDuration)
 		if err != nil {
 			return fmt.Errorf("failed to cut silence: %w", err)
@@ -204,6 +578,42 @@ func runVoidCutter(cmd *cobra.Command, args []string) error {
 		fmt.Println("\nNo silence regions to cut.")
 	}
 
+	// Cutting changes integrated loudness, so re-measure what will be delivered
+	if cfg.PostCutCorrection {
+		fmt.Println("\nRe-measuring loudness after cutting...")
+		correctionResults, err := loudness.CorrectLoudness(audioFiles, normConfig, cfg.PostCutTolerance)
+		if err != nil {
+			return fmt.Errorf("failed to correct loudness: %w", err)
+		}
+
+		loudness.PrintCorrectionSummary(correctionResults, cfg.PostCutTolerance)
+	}
+
+	// Check the processed audio against the preset's loudness rules; format rules
+	// and the loudness range are only checked by the verify subcommand. Mix and
+	// linked modes normalize the program, so the delivered sum is checked instead
+	// of each track.
+	if preset != nil {
+		var complianceResults []*loudness.ComplianceResult
+		if cfg.NormalizeMode == loudness.ModeMix || cfg.NormalizeMode == loudness.ModeLinked {
+			result, err := loudness.MeasureProgramLoudness(audioFiles)
+			if err != nil {
+				return fmt.Errorf("failed to measure program loudness: %w", err)
+			}
+			complianceResults = append(complianceResults, preset.Check(result))
+		} else {
+			for _, audioData := range audioFiles {
+				result, err := loudness.MeasureLoudness(audioData)
+				if err != nil {
+					return fmt.Errorf("failed to measure loudness for %s: %w", audioData.Filename, err)
+				}
+				complianceResults = append(complianceResults, preset.Check(result))
+			}
+		}
+
+		loudness.PrintComplianceSummary(complianceResults)
+	}
+
 	// Generate output files
 	fmt.Println("\nGenerating output files...")
 	for i, audioData := range audioFiles {
@@ -223,6 +633,20 @@ func runVoidCutter(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// validateInputFiles checks that every input file exists and is a WAV file
+func validateInputFiles(files []string) error {
+	for _, file := range files {
+		if _, err := os.Stat(file); os.IsNotExist(err) {
+			return fmt.Errorf("input file not found: %s", file)
+		}
+
+		if !strings.HasSuffix(strings.ToLower(file), ".wav") {
+			return fmt.Errorf("input file must be a WAV file: %s", file)
+		}
+	}
+	return nil
+}
+
 func generateOutputFilename(inputFile, suffix string) string {
 	dir := filepath.Dir(inputFile)
 	basename := strings.TrimSuffix(filepath.Base(inputFile), filepath.Ext(inputFile))
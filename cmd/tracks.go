@@ -0,0 +1,341 @@
+package cmd
+
+import (
+	"fmt"
+	"math"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"void-cutter/internal/denoise"
+	"void-cutter/internal/dynamics"
+	"void-cutter/internal/filter"
+	"void-cutter/internal/hum"
+	"void-cutter/internal/loudness"
+)
+
+// Per-track options are given as "[file=]spec". A spec without a file applies
+// to every input; a file-specific spec takes precedence over it. Files are
+// matched by the path given on the command line or by their base name.
+
+// splitTrackSpec splits a "[file=]spec" value into its file and spec parts
+func splitTrackSpec(value string) (file, spec string) {
+	if i := strings.Index(value, "="); i >= 0 {
+		return value[:i], value[i+1:]
+	}
+	return "", value
+}
+
+// matchesFile reports whether a file given in a per-track option refers to an input file
+func matchesFile(file, inputFile string) bool {
+	return file == inputFile ||
+		filepath.Clean(file) == filepath.Clean(inputFile) ||
+		file == filepath.Base(inputFile)
+}
+
+// trackSpec returns the spec from values that applies to the input file
+func trackSpec(values []string, inputFile string) (string, bool) {
+	spec, found := "", false
+	for _, value := range values {
+		file, s := splitTrackSpec(value)
+		if file == "" {
+			spec, found = s, true
+		}
+	}
+	for _, value := range values {
+		file, s := splitTrackSpec(value)
+		if file != "" && matchesFile(file, inputFile) {
+			spec, found = s, true
+		}
+	}
+	return spec, found
+}
+
+// validateTrackSpecs checks that every file named in a per-track option is an input file
+func validateTrackSpecs(flag string, values []string, inputFiles []string) error {
+	for _, value := range values {
+		file, _ := splitTrackSpec(value)
+		if file == "" {
+			continue
+		}
+
+		matched := false
+		for _, inputFile := range inputFiles {
+			if matchesFile(file, inputFile) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return fmt.Errorf("--%s: %s does not match any input file", flag, file)
+		}
+	}
+	return nil
+}
+
+// matchFileList marks the input files named in a file list option
+func matchFileList(flag string, files []string, inputFiles []string) ([]bool, error) {
+	matches := make([]bool, len(inputFiles))
+	for _, file := range files {
+		matched := false
+		for i, inputFile := range inputFiles {
+			if matchesFile(file, inputFile) {
+				matches[i], matched = true, true
+			}
+		}
+		if !matched {
+			return nil, fmt.Errorf("--%s: %s does not match any input file", flag, file)
+		}
+	}
+	return matches, nil
+}
+
+// applyParams parses a "key:value,key:value" spec into the given fields.
+// The spec "default" leaves every field unchanged.
+func applyParams(spec string, fields map[string]*float64) error {
+	spec = strings.TrimSpace(spec)
+	if spec == "" || spec == "default" {
+		return nil
+	}
+
+	for _, param := range strings.Split(spec, ",") {
+		key, value, ok := strings.Cut(strings.TrimSpace(param), ":")
+		if !ok {
+			return fmt.Errorf("invalid parameter %q (expected key:value)", param)
+		}
+
+		field, known := fields[strings.ToLower(key)]
+		if !known {
+			return fmt.Errorf("unknown parameter %q (available: %s)", key, strings.Join(paramNames(fields), ", "))
+		}
+
+		number, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil {
+			return fmt.Errorf("invalid value for %s: %q", key, value)
+		}
+		*field = number
+	}
+	return nil
+}
+
+// paramNames returns the sorted parameter names of a field map
+func paramNames(fields map[string]*float64) []string {
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// buildTrackConfigs resolves a per-track option for each input file. Files
+// with a spec get the defaults changed by apply; the others get a nil entry.
+func buildTrackConfigs[T any](flag string, values, inputFiles []string, defaults func() T, apply func(config *T, spec string) error) ([]*T, error) {
+	if err := validateTrackSpecs(flag, values, inputFiles); err != nil {
+		return nil, err
+	}
+
+	configs := make([]*T, len(inputFiles))
+	for i, inputFile := range inputFiles {
+		spec, ok := trackSpec(values, inputFile)
+		if !ok {
+			continue
+		}
+
+		config := defaults()
+		if err := apply(&config, spec); err != nil {
+			return nil, fmt.Errorf("--%s for %s: %w", flag, inputFile, err)
+		}
+		configs[i] = &config
+	}
+
+	return configs, nil
+}
+
+// buildCompressorConfigs resolves the --compressor options for each input file
+func buildCompressorConfigs(inputFiles []string) ([]*dynamics.CompressorConfig, error) {
+	return buildTrackConfigs("compressor", cfg.Compressor, inputFiles, dynamics.DefaultCompressorConfig,
+		func(config *dynamics.CompressorConfig, spec string) error {
+			err := applyParams(spec, map[string]*float64{
+				"threshold":     &config.ThresholdDB,
+				"ratio":         &config.Ratio,
+				"knee":          &config.KneeDB,
+				"attack":        &config.AttackMs,
+				"release":       &config.ReleaseMs,
+				"makeup":        &config.MakeupDB,
+				"sidechain-hpf": &config.SidechainHPFHz,
+			})
+			if err != nil {
+				return err
+			}
+			return config.Validate()
+		})
+}
+
+// buildDeEsserConfigs resolves the --deesser options for each input file
+func buildDeEsserConfigs(inputFiles []string) ([]*dynamics.DeEsserConfig, error) {
+	return buildTrackConfigs("deesser", cfg.DeEsser, inputFiles, dynamics.DefaultDeEsserConfig,
+		func(config *dynamics.DeEsserConfig, spec string) error {
+			err := applyParams(spec, map[string]*float64{
+				"threshold": &config.ThresholdDB,
+				"reduction": &config.ReductionDB,
+				"low":       &config.LowHz,
+				"high":      &config.HighHz,
+				"attack":    &config.AttackMs,
+				"release":   &config.ReleaseMs,
+			})
+			if err != nil {
+				return err
+			}
+			return config.Validate()
+		})
+}
+
+// buildGateConfigs resolves the --gate options for each input file
+func buildGateConfigs(inputFiles []string) ([]*dynamics.GateConfig, error) {
+	return buildTrackConfigs("gate", cfg.Gate, inputFiles, dynamics.DefaultGateConfig,
+		func(config *dynamics.GateConfig, spec string) error {
+			err := applyParams(spec, map[string]*float64{
+				"threshold":  &config.ThresholdDB,
+				"hysteresis": &config.HysteresisDB,
+				"hold":       &config.HoldMs,
+				"range":      &config.RangeDB,
+				"ratio":      &config.Ratio,
+				"attack":     &config.AttackMs,
+				"release":    &config.ReleaseMs,
+				"relative":   &config.RelativeMarginDB,
+			})
+			if err != nil {
+				return err
+			}
+			return config.Validate()
+		})
+}
+
+// buildNoiseReductionConfigs resolves the --denoise options for each input file
+func buildNoiseReductionConfigs(inputFiles []string) ([]*denoise.NoiseReductionConfig, error) {
+	return buildTrackConfigs("denoise", cfg.Denoise, inputFiles, denoise.DefaultNoiseReductionConfig,
+		func(config *denoise.NoiseReductionConfig, spec string) error {
+			err := applyParams(spec, map[string]*float64{
+				"reduction":       &config.ReductionDB,
+				"oversubtraction": &config.OverSubtraction,
+				"smoothing":       &config.Smoothing,
+			})
+			if err != nil {
+				return err
+			}
+			return config.Validate()
+		})
+}
+
+// buildFilterChains resolves the --filter options for each input file.
+// Unlike the other per-track options, the spec is a filter chain
+// ("hpf:80,peak:3000:2:1.4"); files without a filter option get no chain.
+func buildFilterChains(inputFiles []string) ([][]filter.FilterSpec, error) {
+	configs, err := buildTrackConfigs("filter", cfg.Filter, inputFiles, filter.DefaultChain,
+		func(chain *[]filter.FilterSpec, spec string) error {
+			spec = strings.TrimSpace(spec)
+			if spec == "" || spec == "default" {
+				return nil
+			}
+
+			parsed, err := filter.ParseFilterChain(spec)
+			if err != nil {
+				return err
+			}
+			*chain = parsed
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	chains := make([][]filter.FilterSpec, len(configs))
+	for i, chain := range configs {
+		if chain != nil {
+			chains[i] = *chain
+		}
+	}
+	return chains, nil
+}
+
+// buildHumConfigs resolves the --dehum options for each input file
+func buildHumConfigs(inputFiles []string) ([]*hum.HumConfig, error) {
+	return buildTrackConfigs("dehum", cfg.Dehum, inputFiles, hum.DefaultHumConfig,
+		func(config *hum.HumConfig, spec string) error {
+			harmonics := float64(config.Harmonics)
+			err := applyParams(spec, map[string]*float64{
+				"fundamental": &config.FundamentalHz,
+				"harmonics":   &harmonics,
+				"q":           &config.Q,
+				"prominence":  &config.MinProminenceDB,
+			})
+			if err != nil {
+				return err
+			}
+			config.Harmonics = int(harmonics)
+			return config.Validate()
+		})
+}
+
+// parseUnitValue parses a number with an optional unit suffix such as "+1.5dB"
+func parseUnitValue(value, unit string) (float64, error) {
+	value = strings.TrimSpace(value)
+	if len(value) >= len(unit) && strings.EqualFold(value[len(value)-len(unit):], unit) {
+		value = strings.TrimSpace(value[:len(value)-len(unit)])
+	}
+	return strconv.ParseFloat(value, 64)
+}
+
+// buildNormalizationOverrides resolves the --gain, --target and --skip-normalize
+// options for each input file. Files without any override get a nil entry.
+func buildNormalizationOverrides(inputFiles []string) ([]*loudness.TrackOverride, error) {
+	if err := validateTrackSpecs("gain", cfg.Gain, inputFiles); err != nil {
+		return nil, err
+	}
+	if err := validateTrackSpecs("target", cfg.Target, inputFiles); err != nil {
+		return nil, err
+	}
+
+	skipped, err := matchFileList("skip-normalize", cfg.SkipNormalize, inputFiles)
+	if err != nil {
+		return nil, err
+	}
+
+	overrides := make([]*loudness.TrackOverride, len(inputFiles))
+	for i, inputFile := range inputFiles {
+		override := loudness.TrackOverride{Skip: skipped[i]}
+		changed := skipped[i]
+
+		if spec, ok := trackSpec(cfg.Gain, inputFile); ok {
+			gainDB, err := parseUnitValue(spec, "dB")
+			if err != nil {
+				return nil, fmt.Errorf("--gain for %s: invalid gain %q", inputFile, spec)
+			}
+			if math.Abs(gainDB) > 20 {
+				return nil, fmt.Errorf("--gain for %s: gain offset must be between -20 and +20 dB", inputFile)
+			}
+			override.GainOffsetDB = gainDB
+			changed = true
+		}
+
+		if spec, ok := trackSpec(cfg.Target, inputFile); ok {
+			target, err := parseUnitValue(spec, "LUFS")
+			if err != nil {
+				return nil, fmt.Errorf("--target for %s: invalid target %q", inputFile, spec)
+			}
+			if err := loudness.ValidateTargetLoudness(target); err != nil {
+				return nil, fmt.Errorf("--target for %s: %w", inputFile, err)
+			}
+			override.TargetLUFS = target
+			changed = true
+		}
+
+		if changed {
+			overrides[i] = &override
+		}
+	}
+
+	return overrides, nil
+}
@@ -0,0 +1,144 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"void-cutter/internal/audio"
+	"void-cutter/internal/loudness"
+
+	"github.com/spf13/cobra"
+)
+
+// verifyOptions holds the flags of the verify subcommand
+type verifyOptions struct {
+	Preset           string
+	TargetLoudness   float64 // LUFS
+	Tolerance        float64 // LU
+	MaxTruePeak      float64 // dBTP
+	MaxLoudnessRange float64 // LU (0 disables)
+	SampleRate       int     // Hz (0 disables)
+	BitDepth         int     // bits (0 disables)
+}
+
+var verifyOpts = verifyOptions{
+	TargetLoudness: -16.0,
+	Tolerance:      1.0,
+	MaxTruePeak:    -1.0,
+}
+
+// verifyCmd checks already produced files against loudness delivery requirements
+var verifyCmd = &cobra.Command{
+	Use:   "verify [OPTIONS] <file1.wav> <file2.wav> ...",
+	Short: "Check files against loudness delivery requirements",
+	Long: `verify measures already produced WAV files against a target or preset
+(integrated loudness ± tolerance, true-peak ceiling, loudness range, sample rate and
+bit depth) without modifying them. It prints pass/fail per file and exits with a
+non-zero status if any file fails, so it can gate publishing scripts.`,
+	Args:         cobra.MinimumNArgs(1),
+	SilenceUsage: true,
+	RunE:         runVerify,
+}
+
+func init() {
+	rootCmd.AddCommand(verifyCmd)
+
+	verifyCmd.Flags().StringVarP(&verifyOpts.Preset, "preset", "p", verifyOpts.Preset,
+		"Loudness delivery preset ("+strings.Join(loudness.PresetNames(), ", ")+")")
+	verifyCmd.Flags().Float64VarP(&verifyOpts.TargetLoudness, "target-loudness", "l", verifyOpts.TargetLoudness,
+		"Target integrated loudness in LUFS")
+	verifyCmd.Flags().Float64Var(&verifyOpts.Tolerance, "tolerance", verifyOpts.Tolerance,
+		"Allowed deviation from the target loudness in LU")
+	verifyCmd.Flags().Float64Var(&verifyOpts.MaxTruePeak, "max-true-peak", verifyOpts.MaxTruePeak,
+		"Maximum true peak in dBTP")
+	verifyCmd.Flags().Float64Var(&verifyOpts.MaxLoudnessRange, "max-lra", verifyOpts.MaxLoudnessRange,
+		"Maximum loudness range in LU (0 to skip)")
+	verifyCmd.Flags().IntVar(&verifyOpts.SampleRate, "sample-rate", verifyOpts.SampleRate,
+		"Required sample rate in Hz (0 to skip)")
+	verifyCmd.Flags().IntVar(&verifyOpts.BitDepth, "bit-depth", verifyOpts.BitDepth,
+		"Required bit depth (0 to skip)")
+}
+
+func runVerify(cmd *cobra.Command, args []string) error {
+	// Start from the preset (if any); explicitly given flags take precedence
+	preset := &loudness.Preset{
+		Name:            "custom",
+		TargetLUFS:      verifyOpts.TargetLoudness,
+		TruePeakCeiling: verifyOpts.MaxTruePeak,
+		Rules: loudness.ComplianceRules{
+			LoudnessTolerance: verifyOpts.Tolerance,
+			MaxTruePeak:       verifyOpts.MaxTruePeak,
+		},
+	}
+
+	if verifyOpts.Preset != "" {
+		p, err := loudness.GetPreset(verifyOpts.Preset)
+		if err != nil {
+			return fmt.Errorf("configuration validation failed: %w", err)
+		}
+		presetCopy := *p
+		preset = &presetCopy
+	}
+
+	flags := cmd.Flags()
+	if flags.Changed("target-loudness") {
+		preset.TargetLUFS = verifyOpts.TargetLoudness
+	}
+	if flags.Changed("tolerance") {
+		preset.Rules.LoudnessTolerance = verifyOpts.Tolerance
+	}
+	if flags.Changed("max-true-peak") {
+		preset.Rules.MaxTruePeak = verifyOpts.MaxTruePeak
+	}
+	if flags.Changed("max-lra") {
+		preset.Rules.MaxLoudnessRange = verifyOpts.MaxLoudnessRange
+	}
+	if flags.Changed("sample-rate") {
+		preset.Rules.SampleRate = verifyOpts.SampleRate
+	}
+	if flags.Changed("bit-depth") {
+		preset.Rules.BitDepth = verifyOpts.BitDepth
+	}
+
+	if err := loudness.ValidateTargetLoudness(preset.TargetLUFS); err != nil {
+		return fmt.Errorf("configuration validation failed: %w", err)
+	}
+
+	if err := validateInputFiles(args); err != nil {
+		return err
+	}
+
+	fmt.Printf("Verifying %d file(s) against %s\n", len(args), preset.Name)
+	var results []*loudness.ComplianceResult
+
+	for i, file := range args {
+		fmt.Printf("[%d/%d] Measuring: %s", i+1, len(args), file)
+
+		audioData, err := audio.LoadWAV(file)
+		if err != nil {
+			return fmt.Errorf("failed to load %s: %w", file, err)
+		}
+
+		result, err := preset.Verify(audioData)
+		if err != nil {
+			return fmt.Errorf("failed to check %s: %w", file, err)
+		}
+
+		results = append(results, result)
+		fmt.Printf(" ✓\n")
+	}
+
+	loudness.PrintComplianceSummary(results)
+
+	failed := 0
+	for _, result := range results {
+		if !result.Passed() {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d file(s) failed compliance checks", failed, len(results))
+	}
+
+	return nil
+}
@@ -0,0 +1,57 @@
+package audio
+
+// FullScale returns the magnitude of a full-scale sample for the bit depth
+func (ad *AudioData) FullScale() float64 {
+	switch ad.BitDepth {
+	case 16:
+		return 32768.0 // 2^15
+	case 24:
+		return 8388608.0 // 2^23
+	case 32:
+		return 2147483648.0 // 2^31
+	default:
+		return 32768.0 // Default to 16-bit
+	}
+}
+
+// ToFloat returns the samples normalized to the [-1, 1] range (interleaved)
+func (ad *AudioData) ToFloat() []float64 {
+	fullScale := ad.FullScale()
+
+	samples := make([]float64, len(ad.Samples))
+	for i, sample := range ad.Samples {
+		samples[i] = float64(sample) / fullScale
+	}
+	return samples
+}
+
+// FromFloat replaces the samples with normalized float values and returns
+// the number of samples that had to be clipped to fit the bit depth
+func (ad *AudioData) FromFloat(samples []float64) int {
+	fullScale := ad.FullScale()
+	maxValue := fullScale - 1
+	minValue := -fullScale
+
+	if len(ad.Samples) != len(samples) {
+		ad.Samples = make([]int32, len(samples))
+	}
+
+	clippedSamples := 0
+	for i, sample := range samples {
+		value := sample * fullScale
+		if value > maxValue {
+			value = maxValue
+			clippedSamples++
+		} else if value < minValue {
+			value = minValue
+			clippedSamples++
+		}
+		ad.Samples[i] = int32(value)
+	}
+
+	if ad.SampleRate > 0 && ad.Channels > 0 {
+		ad.Duration = float64(len(ad.Samples)) / float64(ad.SampleRate) / float64(ad.Channels)
+	}
+
+	return clippedSamples
+}
@@ -11,12 +11,57 @@ type Config struct {
 	OutputSuffix string
 
 	// Loudness normalization settings
-	TargetLoudness float64 // LUFS
+	Preset          string  // Loudness delivery preset name (empty for none)
+	TargetLoudness  float64 // LUFS
+	TruePeakCeiling float64 // dBTP
+	NormalizeMode   string  // "independent", "mix" or "linked"
+	SpeechGated     bool    // Measure loudness over active speech only
+
+	// Per-track normalization overrides ("[file=]value", see cmd/tracks.go)
+	Gain          []string // Gain offsets in dB
+	Target        []string // Loudness targets in LUFS
+	SkipNormalize []string // Files left untouched by normalization
+
+	// Input clipping settings
+	Declip     bool // Reconstruct clipped peaks before any processing
+	ClipMinRun int  // Consecutive samples at the peak that count as clipping
+
+	// Per-track processing settings ("[file=]spec", see cmd/tracks.go)
+	Filter     []string
+	Dehum      []string
+	Denoise    []string
+	Gate       []string
+	DeEsser    []string
+	Compressor []string
+
+	// Speech leveler settings
+	Leveler         bool    // Ride gain towards the target during speech
+	LevelerMaxBoost float64 // dB
+	LevelerMaxCut   float64 // dB
+	LevelerSpeed    float64 // seconds
+
+	// Post-cut loudness correction settings
+	PostCutCorrection bool    // Re-measure and correct loudness after cutting
+	PostCutTolerance  float64 // LU
 
 	// Silence detection settings
-	SilenceThreshold    float64 // dBFS
-	MinSilenceDuration  int     // milliseconds
-	KeepSilenceDuration int     // milliseconds
+	DetectionMode       string   // Silence detector name ("rms", "adaptive", "vad" or a registered one)
+	SpeechBandDetection bool     // Detection energy within the speech band only
+	DetectionChannels   string   // "mix", "any" or 1-based channel numbers like "1,2"
+	RefineBoundaries    bool     // Sample-accurate region boundaries and snapped cut points
+	SilenceQuorum       int      // Silent tracks needed for common silence (0 all, N at least N, -N all but N)
+	ExcludeDetection    []string // Files cut in sync but left out of the silence decision
+	SilenceThreshold    float64  // dBFS
+	ThresholdRelativeTo string   // "", "integrated" or "speech"
+	ThresholdBelow      float64  // dB below the reference loudness
+	AutoThreshold       bool     // Shorthand for the adaptive detection mode
+	ThresholdMargin     float64  // dB above the noise floor
+	SilenceHysteresis   float64  // dB above the threshold needed to break silence
+	MinBurstDuration    int      // milliseconds of sound needed to break silence
+	SilenceHold         int      // milliseconds
+	SilenceRelease      int      // milliseconds
+	MinSilenceDuration  int      // milliseconds
+	KeepSilenceDuration int      // milliseconds
 }
 
 // DefaultConfig returns a configuration with default values
@@ -24,7 +69,18 @@ func DefaultConfig() *Config {
 	return &Config{
 		OutputSuffix:        "_edited",
 		TargetLoudness:      -16.0,
+		TruePeakCeiling:     -0.1,
+		NormalizeMode:       "independent",
+		PostCutTolerance:    0.5,
+		ClipMinRun:          3,
+		LevelerMaxBoost:     6.0,
+		LevelerMaxCut:       6.0,
+		LevelerSpeed:        2.0,
+		DetectionMode:       "rms",
+		DetectionChannels:   "mix",
 		SilenceThreshold:    -50.0,
+		ThresholdBelow:      25.0,
+		ThresholdMargin:     10.0,
 		MinSilenceDuration:  500,
 		KeepSilenceDuration: 250,
 	}
@@ -36,10 +92,50 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("no input files specified")
 	}
 
+	switch c.NormalizeMode {
+	case "independent", "mix", "linked":
+	default:
+		return fmt.Errorf("normalize mode must be one of: independent, mix, linked")
+	}
+
+	if c.LevelerMaxBoost < 0 || c.LevelerMaxCut < 0 {
+		return fmt.Errorf("leveler max boost and max cut must be non-negative")
+	}
+
+	if c.LevelerSpeed <= 0 {
+		return fmt.Errorf("leveler speed must be positive")
+	}
+
+	if c.ClipMinRun < 2 {
+		return fmt.Errorf("clip minimum run must be at least 2 samples")
+	}
+
+	if c.PostCutTolerance < 0 {
+		return fmt.Errorf("post-cut tolerance must be non-negative")
+	}
+
 	if c.SilenceThreshold < -120.0 || c.SilenceThreshold > 0.0 {
 		return fmt.Errorf("silence threshold must be between -120.0 and 0.0 dBFS")
 	}
 
+	switch c.ThresholdRelativeTo {
+	case "", "integrated", "speech":
+	default:
+		return fmt.Errorf("threshold reference must be one of: integrated, speech")
+	}
+
+	if c.ThresholdBelow <= 0 || c.ThresholdBelow > 80 {
+		return fmt.Errorf("threshold below reference must be between 0 and 80 dB")
+	}
+
+	if c.ThresholdMargin <= 0 || c.ThresholdMargin > 60 {
+		return fmt.Errorf("threshold margin must be between 0 and 60 dB")
+	}
+
+	if c.SilenceHysteresis < 0 || c.MinBurstDuration < 0 || c.SilenceHold < 0 || c.SilenceRelease < 0 {
+		return fmt.Errorf("silence hysteresis, min burst, hold and release must be non-negative")
+	}
+
 	if c.MinSilenceDuration <= 0 {
 		return fmt.Errorf("minimum silence duration must be positive")
 	}
@@ -0,0 +1,276 @@
+package declip
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"void-cutter/internal/audio"
+)
+
+const (
+	minClipLevel    = 0.5  // Flat runs below -6 dBFS are not treated as clipping
+	maxOvershoot    = 4.0  // Reconstructed peaks are limited to 12 dB above the clip level
+	headroomCeiling = 0.99 // Peak after declipping, relative to full scale
+	maxListedRuns   = 10   // Runs printed per track in the summary
+)
+
+// ClipRun is a run of consecutive samples at the clip level in one channel
+type ClipRun struct {
+	Channel    int
+	StartFrame int
+	Length     int // Samples
+	StartTime  float64
+	Positive   bool // Clipped at the positive (true) or negative (false) level
+}
+
+// ClippingReport contains the clipping found in a track
+type ClippingReport struct {
+	Runs           []ClipRun
+	ClippedSamples int
+	Filename       string
+}
+
+// DeclipResult contains the results of declipping
+type DeclipResult struct {
+	RepairedRuns    int
+	RepairedSamples int
+	PeakDB          float64 // Reconstructed peak relative to full scale (before attenuation)
+	AttenuationDB   float64 // Gain reduction applied to fit the reconstructed peaks
+	Filename        string
+}
+
+// DetectClipping finds runs of at least minRun consecutive samples at a channel's
+// positive or negative peak. Flat tops at the peak indicate clipping, whether at
+// full scale or at a lower level after the clipped recording was turned down.
+func DetectClipping(audioData *audio.AudioData, minRun int) (*ClippingReport, error) {
+	if audioData == nil {
+		return nil, fmt.Errorf("audio data is nil")
+	}
+
+	if minRun < 2 {
+		return nil, fmt.Errorf("minimum clip run must be at least 2 samples")
+	}
+
+	channels := audioData.Channels
+	frameCount := audioData.GetFrameCount()
+	minLevel := int32(audioData.FullScale() * minClipLevel)
+	report := &ClippingReport{Filename: audioData.Filename}
+
+	for ch := 0; ch < channels; ch++ {
+		var maxSample, minSample int32
+		for frame := 0; frame < frameCount; frame++ {
+			sample := audioData.Samples[frame*channels+ch]
+			maxSample = max(maxSample, sample)
+			minSample = min(minSample, sample)
+		}
+
+		// Within one step of the peak counts as clipped
+		levels := []struct {
+			positive bool
+			active   bool
+			clipped  func(int32) bool
+		}{
+			{true, maxSample >= minLevel, func(s int32) bool { return s >= maxSample-1 }},
+			{false, -int64(minSample) >= int64(minLevel), func(s int32) bool { return s <= minSample+1 }},
+		}
+
+		for _, level := range levels {
+			if !level.active {
+				continue
+			}
+
+			runStart := -1
+			for frame := 0; frame <= frameCount; frame++ {
+				clipped := frame < frameCount && level.clipped(audioData.Samples[frame*channels+ch])
+				if clipped && runStart < 0 {
+					runStart = frame
+				}
+				if !clipped && runStart >= 0 {
+					if length := frame - runStart; length >= minRun {
+						report.Runs = append(report.Runs, ClipRun{
+							Channel:    ch,
+							StartFrame: runStart,
+							Length:     length,
+							StartTime:  float64(runStart) / float64(audioData.SampleRate),
+							Positive:   level.positive,
+						})
+						report.ClippedSamples += length
+					}
+					runStart = -1
+				}
+			}
+		}
+	}
+
+	sort.SliceStable(report.Runs, func(i, j int) bool {
+		return report.Runs[i].StartFrame < report.Runs[j].StartFrame
+	})
+	return report, nil
+}
+
+// Declip reconstructs the clipped peaks of the reported runs with cubic
+// interpolation through the two samples on either side of each run. The
+// reconstructed peaks exceed the clip level, so the whole track is attenuated
+// as needed to fit them; this is meant to run before any gain is applied.
+func Declip(audioData *audio.AudioData, report *ClippingReport) (*DeclipResult, error) {
+	if audioData == nil || report == nil {
+		return nil, fmt.Errorf("audio data or clipping report is nil")
+	}
+
+	channels := audioData.Channels
+	frameCount := audioData.GetFrameCount()
+	samples := audioData.ToFloat()
+	result := &DeclipResult{Filename: audioData.Filename}
+
+	for _, run := range report.Runs {
+		before, after := run.StartFrame-2, run.StartFrame+run.Length
+		if before < 0 || after+1 >= frameCount {
+			continue
+		}
+
+		at := func(frame int) float64 { return samples[frame*channels+run.Channel] }
+		xs := []float64{-2, -1, float64(run.Length), float64(run.Length + 1)}
+		ys := []float64{at(before), at(before + 1), at(after), at(after + 1)}
+		clipLevel := math.Abs(at(run.StartFrame))
+
+		for i := 0; i < run.Length; i++ {
+			value := math.Abs(lagrange(xs, ys, float64(i)))
+			value = math.Min(math.Max(value, clipLevel), clipLevel*maxOvershoot)
+			if !run.Positive {
+				value = -value
+			}
+			samples[(run.StartFrame+i)*channels+run.Channel] = value
+		}
+
+		result.RepairedRuns++
+		result.RepairedSamples += run.Length
+	}
+
+	peak := 0.0
+	for _, sample := range samples {
+		peak = math.Max(peak, math.Abs(sample))
+	}
+	if peak > 0 {
+		result.PeakDB = 20 * math.Log10(peak)
+	}
+
+	if peak > headroomCeiling {
+		gain := headroomCeiling / peak
+		for i := range samples {
+			samples[i] *= gain
+		}
+		result.AttenuationDB = -20 * math.Log10(gain)
+	}
+
+	audioData.FromFloat(samples)
+	return result, nil
+}
+
+// lagrange evaluates the polynomial through the points (xs, ys) at x
+func lagrange(xs, ys []float64, x float64) float64 {
+	var sum float64
+	for i := range xs {
+		term := ys[i]
+		for j := range xs {
+			if i != j {
+				term *= (x - xs[j]) / (xs[i] - xs[j])
+			}
+		}
+		sum += term
+	}
+	return sum
+}
+
+// DetectClippingMultipleAudio checks every track for input clipping
+func DetectClippingMultipleAudio(audioFiles []*audio.AudioData, minRun int) ([]*ClippingReport, error) {
+	if len(audioFiles) == 0 {
+		return nil, fmt.Errorf("no audio files provided")
+	}
+
+	reports := make([]*ClippingReport, len(audioFiles))
+	for i, audioData := range audioFiles {
+		report, err := DetectClipping(audioData, minRun)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check clipping in %s: %w", audioData.Filename, err)
+		}
+		reports[i] = report
+	}
+
+	return reports, nil
+}
+
+// DeclipMultipleAudio declips every track that has clipping.
+// Tracks without clipping get a nil result and are left untouched.
+func DeclipMultipleAudio(audioFiles []*audio.AudioData, reports []*ClippingReport) ([]*DeclipResult, error) {
+	if len(reports) != len(audioFiles) {
+		return nil, fmt.Errorf("expected %d clipping reports, got %d", len(audioFiles), len(reports))
+	}
+
+	results := make([]*DeclipResult, len(audioFiles))
+	for i, audioData := range audioFiles {
+		if len(reports[i].Runs) == 0 {
+			continue
+		}
+
+		result, err := Declip(audioData, reports[i])
+		if err != nil {
+			return nil, fmt.Errorf("failed to declip %s: %w", audioData.Filename, err)
+		}
+		results[i] = result
+	}
+
+	return results, nil
+}
+
+// Print displays the clipping runs of a track with their timestamps
+func (cr *ClippingReport) Print() {
+	if len(cr.Runs) == 0 {
+		fmt.Printf("%s: no clipping ✓\n", cr.Filename)
+		return
+	}
+
+	fmt.Printf("%s: ⚠️  %d clipped runs (%d samples)\n", cr.Filename, len(cr.Runs), cr.ClippedSamples)
+	for i, run := range cr.Runs {
+		if i == maxListedRuns {
+			fmt.Printf("  ... and %d more\n", len(cr.Runs)-maxListedRuns)
+			break
+		}
+
+		sign := "+"
+		if !run.Positive {
+			sign = "-"
+		}
+		fmt.Printf("  %8.3fs  ch%d  %s%d samples\n", run.StartTime, run.Channel+1, sign, run.Length)
+	}
+}
+
+// PrintClippingSummary displays the clipping found in every track
+func PrintClippingSummary(reports []*ClippingReport) {
+	fmt.Printf("\nInput Clipping:\n")
+
+	for i, report := range reports {
+		fmt.Printf("[%d] ", i+1)
+		report.Print()
+	}
+}
+
+// PrintDeclipSummary displays a summary of all declip results.
+// Tracks without a result had no clipping.
+func PrintDeclipSummary(audioFiles []*audio.AudioData, results []*DeclipResult) {
+	fmt.Printf("\nDeclip Summary:\n")
+
+	for i, result := range results {
+		if result == nil {
+			fmt.Printf("[%d] %s: no clipping\n", i+1, audioFiles[i].Filename)
+			continue
+		}
+
+		fmt.Printf("[%d] %s: repaired %d runs (%d samples), reconstructed peak %+.1f dBFS",
+			i+1, result.Filename, result.RepairedRuns, result.RepairedSamples, result.PeakDB)
+		if result.AttenuationDB > 0 {
+			fmt.Printf(", attenuated by %.1f dB", result.AttenuationDB)
+		}
+		fmt.Println(" ✓")
+	}
+}
@@ -0,0 +1,267 @@
+package denoise
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"void-cutter/internal/audio"
+	"void-cutter/internal/silence"
+	"void-cutter/internal/spectral"
+)
+
+const (
+	frameMs           = 32   // STFT frame length in milliseconds
+	minProfileFrames  = 8    // Frames needed for a noise profile from silence regions
+	fallbackQuantile  = 0.10 // Quietest fraction of frames used when silence is too short
+	noiseProfileFloor = 1e-20
+	hannMeanSquare    = 0.5 // Mean of the squared square-root Hann window
+)
+
+// NoiseReductionConfig holds parameters for spectral noise reduction
+type NoiseReductionConfig struct {
+	ReductionDB     float64 // Maximum attenuation of noise-only bins
+	OverSubtraction float64 // Noise estimate multiplier (higher removes more, with more artifacts)
+	Smoothing       float64 // Temporal smoothing of the gains (0 to 1) to avoid musical noise
+}
+
+// NoiseReductionResult contains the results of noise reduction
+type NoiseReductionResult struct {
+	NoiseFloorDB     float64 // Estimated noise floor level in dBFS
+	NoiseReductionDB float64 // Reduction of the noise floor in the profile frames
+	ProfileSeconds   float64 // Amount of audio the noise profile was learned from
+	FromSilence      bool    // Profile learned from detected silence (false: quietest frames)
+	ClippedSamples   int
+	Filename         string
+}
+
+// DefaultNoiseReductionConfig returns default noise reduction settings
+func DefaultNoiseReductionConfig() NoiseReductionConfig {
+	return NoiseReductionConfig{
+		ReductionDB:     12.0,
+		OverSubtraction: 1.5,
+		Smoothing:       0.5,
+	}
+}
+
+// Validate checks if the noise reduction settings are usable
+func (c NoiseReductionConfig) Validate() error {
+	if c.ReductionDB < 0 || c.ReductionDB > 60 {
+		return fmt.Errorf("noise reduction must be between 0 and 60 dB")
+	}
+
+	if c.OverSubtraction <= 0 {
+		return fmt.Errorf("noise over-subtraction must be positive")
+	}
+
+	if c.Smoothing < 0 || c.Smoothing >= 1 {
+		return fmt.Errorf("noise gain smoothing must be between 0 and 1 (exclusive)")
+	}
+
+	return nil
+}
+
+// ReduceNoise removes stationary noise (hiss, fans) from audio data in place.
+// A per-channel noise spectrum is learned from the given silence regions (or the
+// quietest frames if the regions are too short) and removed with a Wiener-style
+// spectral subtraction gain limited to the configured reduction.
+func ReduceNoise(audioData *audio.AudioData, regions []silence.SilenceRegion, config NoiseReductionConfig) (*NoiseReductionResult, error) {
+	if audioData == nil {
+		return nil, fmt.Errorf("audio data is nil")
+	}
+
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	stft := spectral.NewSTFT(audioData.SampleRate * frameMs / 1000)
+	channels := audioData.Channels
+	samples := audioData.ToFloat()
+	floorGain := math.Pow(10, -config.ReductionDB/20.0)
+
+	result := &NoiseReductionResult{Filename: audioData.Filename}
+	var noiseBefore, noiseAfter, profilePower float64
+	profileBins := 0
+
+	for ch := 0; ch < channels; ch++ {
+		signal := spectral.Deinterleave(samples, channels, ch)
+		profile, profileFrames, fromSilence := learnNoiseProfile(stft, signal, regions)
+		result.FromSilence = fromSilence
+		result.ProfileSeconds = float64(len(profileFrames)*stft.HopSize) / float64(audioData.SampleRate)
+
+		isProfileFrame := make(map[int]bool, len(profileFrames))
+		for _, frame := range profileFrames {
+			isProfileFrame[frame] = true
+		}
+		for _, power := range profile {
+			profilePower += power
+			profileBins++
+		}
+
+		// Spectral subtraction with temporally smoothed gains
+		gains := make([]float64, len(profile))
+		for i := range gains {
+			gains[i] = 1
+		}
+
+		output := stft.Process(signal, func(frame int, spectrum []complex128) {
+			power := spectral.PowerSpectrum(spectrum)
+			for bin := range gains {
+				raw := 1 - config.OverSubtraction*profile[bin]/math.Max(power[bin], noiseProfileFloor)
+				raw = math.Max(raw, floorGain)
+				gains[bin] = config.Smoothing*gains[bin] + (1-config.Smoothing)*raw
+
+				spectrum[bin] *= complex(gains[bin], 0)
+				if bin > 0 && bin < len(spectrum)-bin {
+					spectrum[len(spectrum)-bin] *= complex(gains[bin], 0)
+				}
+
+				if isProfileFrame[frame] {
+					noiseBefore += power[bin]
+					noiseAfter += power[bin] * gains[bin] * gains[bin]
+				}
+			}
+		})
+
+		spectral.Interleave(samples, channels, ch, output)
+	}
+
+	// Noise floor: average profile power per bin, expressed as a time-domain level (Parseval)
+	if profileBins > 0 {
+		meanSquare := profilePower / float64(profileBins) / float64(stft.FrameSize) / hannMeanSquare
+		result.NoiseFloorDB = 10 * math.Log10(math.Max(meanSquare, noiseProfileFloor))
+	}
+	if noiseBefore > 0 && noiseAfter > 0 {
+		result.NoiseReductionDB = 10 * math.Log10(noiseBefore/noiseAfter)
+	}
+
+	result.ClippedSamples = audioData.FromFloat(samples)
+	return result, nil
+}
+
+// learnNoiseProfile averages the power spectra of the frames that lie entirely
+// inside silence regions, or of the quietest frames if the regions do not provide
+// enough of them. Returns the profile, the frames used and whether they came from silence.
+func learnNoiseProfile(stft *spectral.STFT, signal []float64, regions []silence.SilenceRegion) ([]float64, []int, bool) {
+	profile := make([]float64, stft.FrameSize/2+1)
+	frameCount := stft.FrameCount(len(signal))
+
+	var frames []int
+	for frame := 0; frame < frameCount; frame++ {
+		start := stft.FrameStart(frame)
+		end := start + stft.FrameSize
+		if start < 0 || end > len(signal) {
+			continue
+		}
+		for _, region := range regions {
+			if start >= region.StartFrame && end <= region.EndFrame {
+				frames = append(frames, frame)
+				break
+			}
+		}
+	}
+
+	fromSilence := len(frames) >= minProfileFrames
+	if !fromSilence {
+		frames = quietestFrames(stft, signal)
+	}
+
+	if len(frames) == 0 {
+		return profile, nil, fromSilence
+	}
+
+	for _, frame := range frames {
+		for bin, power := range spectral.PowerSpectrum(stft.Analyze(signal, frame)) {
+			profile[bin] += power
+		}
+	}
+	for bin := range profile {
+		profile[bin] /= float64(len(frames))
+	}
+	return profile, frames, fromSilence
+}
+
+// quietestFrames returns the quietest non-silent fraction of frames by energy
+func quietestFrames(stft *spectral.STFT, signal []float64) []int {
+	type frameEnergy struct {
+		frame  int
+		energy float64
+	}
+
+	var energies []frameEnergy
+	for frame := 0; frame < stft.FrameCount(len(signal)); frame++ {
+		start := max(stft.FrameStart(frame), 0)
+		end := min(stft.FrameStart(frame)+stft.FrameSize, len(signal))
+
+		var energy float64
+		for _, sample := range signal[start:end] {
+			energy += sample * sample
+		}
+		if energy > 0 {
+			energies = append(energies, frameEnergy{frame, energy})
+		}
+	}
+	sort.Slice(energies, func(i, j int) bool { return energies[i].energy < energies[j].energy })
+
+	count := max(int(float64(len(energies))*fallbackQuantile), min(minProfileFrames, len(energies)))
+	frames := make([]int, count)
+	for i, fe := range energies[:count] {
+		frames[i] = fe.frame
+	}
+	return frames
+}
+
+// ReduceNoiseMultipleAudio reduces noise in each track with its own settings.
+// A nil entry in configs leaves the corresponding track untouched.
+func ReduceNoiseMultipleAudio(audioFiles []*audio.AudioData, regions []silence.SilenceRegion, configs []*NoiseReductionConfig) ([]*NoiseReductionResult, error) {
+	if len(audioFiles) == 0 {
+		return nil, fmt.Errorf("no audio files provided")
+	}
+
+	if len(configs) != len(audioFiles) {
+		return nil, fmt.Errorf("expected %d noise reduction settings, got %d", len(audioFiles), len(configs))
+	}
+
+	results := make([]*NoiseReductionResult, len(audioFiles))
+	for i, audioData := range audioFiles {
+		if configs[i] == nil {
+			continue
+		}
+
+		result, err := ReduceNoise(audioData, regions, *configs[i])
+		if err != nil {
+			return nil, fmt.Errorf("failed to reduce noise in %s: %w", audioData.Filename, err)
+		}
+		results[i] = result
+	}
+
+	return results, nil
+}
+
+// PrintNoiseReductionSummary displays a summary of all noise reduction results.
+// Tracks without a result were not processed.
+func PrintNoiseReductionSummary(audioFiles []*audio.AudioData, results []*NoiseReductionResult) {
+	fmt.Printf("\nNoise Reduction Summary:\n")
+
+	for i, result := range results {
+		if result == nil {
+			fmt.Printf("[%d] %s: bypassed\n", i+1, audioFiles[i].Filename)
+			continue
+		}
+
+		source := "detected silence"
+		if !result.FromSilence {
+			source = "quietest frames"
+		}
+
+		fmt.Printf("[%d] %s: noise floor %.1f dBFS, reduced by %.1f dB (profile: %.2fs of %s)",
+			i+1, result.Filename, result.NoiseFloorDB, result.NoiseReductionDB, result.ProfileSeconds, source)
+
+		if result.ClippedSamples > 0 {
+			fmt.Printf(" ⚠️  %d samples clipped", result.ClippedSamples)
+		} else {
+			fmt.Printf(" ✓")
+		}
+		fmt.Println()
+	}
+}
@@ -0,0 +1,233 @@
+package dynamics
+
+import (
+	"fmt"
+	"math"
+
+	"void-cutter/internal/audio"
+	"void-cutter/internal/filter"
+)
+
+// CompressorConfig holds parameters for the downward compressor
+type CompressorConfig struct {
+	ThresholdDB    float64 // Level above which gain is reduced, in dBFS
+	Ratio          float64 // Compression ratio (e.g. 3 for 3:1)
+	KneeDB         float64 // Soft knee width in dB (0 for a hard knee)
+	AttackMs       float64 // Time to reach gain reduction
+	ReleaseMs      float64 // Time to recover from gain reduction
+	MakeupDB       float64 // Gain applied after compression
+	SidechainHPFHz float64 // High-pass on the detector signal in Hz (0 disables)
+}
+
+// CompressionResult contains the results of compression
+type CompressionResult struct {
+	MaxGainReductionDB float64 // Largest gain reduction applied
+	AvgGainReductionDB float64 // Average gain reduction while compressing
+	CompressingRatio   float64 // Fraction of the track where gain was reduced
+	MakeupDB           float64
+	ClippedSamples     int
+	Filename           string
+}
+
+// DefaultCompressorConfig returns default compressor settings for voice tracks
+func DefaultCompressorConfig() CompressorConfig {
+	return CompressorConfig{
+		ThresholdDB:    -20.0,
+		Ratio:          3.0,
+		KneeDB:         6.0,
+		AttackMs:       10.0,
+		ReleaseMs:      150.0,
+		MakeupDB:       0.0,
+		SidechainHPFHz: 100.0,
+	}
+}
+
+// Validate checks if the compressor settings are usable
+func (c CompressorConfig) Validate() error {
+	if c.ThresholdDB > 0 || c.ThresholdDB < -80 {
+		return fmt.Errorf("compressor threshold must be between -80 and 0 dBFS")
+	}
+
+	if c.Ratio < 1 {
+		return fmt.Errorf("compressor ratio must be at least 1")
+	}
+
+	if c.KneeDB < 0 {
+		return fmt.Errorf("compressor knee must be non-negative")
+	}
+
+	if c.AttackMs <= 0 || c.ReleaseMs <= 0 {
+		return fmt.Errorf("compressor attack and release must be positive")
+	}
+
+	if c.SidechainHPFHz < 0 {
+		return fmt.Errorf("compressor sidechain high-pass must be non-negative")
+	}
+
+	return nil
+}
+
+// Compress applies downward compression to audio data in place. The detector
+// takes the loudest channel (linked stereo) after an optional sidechain
+// high-pass, so that plosives and rumble do not trigger gain reduction.
+func Compress(audioData *audio.AudioData, config CompressorConfig) (*CompressionResult, error) {
+	if audioData == nil {
+		return nil, fmt.Errorf("audio data is nil")
+	}
+
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	channels := audioData.Channels
+	frameCount := audioData.GetFrameCount()
+	sampleRate := float64(audioData.SampleRate)
+
+	// Sidechain high-pass filter state per channel
+	var sidechain []*filter.Biquad
+	if config.SidechainHPFHz > 0 {
+		for ch := 0; ch < channels; ch++ {
+			sidechain = append(sidechain, filter.NewHighPass(config.SidechainHPFHz, math.Sqrt2/2, sampleRate))
+		}
+	}
+
+	attackCoeff := timeCoefficient(config.AttackMs, sampleRate)
+	releaseCoeff := timeCoefficient(config.ReleaseMs, sampleRate)
+	makeup := math.Pow(10, config.MakeupDB/20.0)
+
+	samples := audioData.ToFloat()
+	result := &CompressionResult{
+		MakeupDB: config.MakeupDB,
+		Filename: audioData.Filename,
+	}
+
+	smoothedGR := 0.0 // Current gain change in dB (<= 0)
+	var totalGR float64
+	compressingFrames := 0
+
+	for frame := 0; frame < frameCount; frame++ {
+		// Detector: peak of the (filtered) channels
+		peak := 0.0
+		for ch := 0; ch < channels; ch++ {
+			detector := samples[frame*channels+ch]
+			if sidechain != nil {
+				detector = sidechain[ch].Process(detector)
+			}
+			peak = math.Max(peak, math.Abs(detector))
+		}
+
+		targetGR := 0.0
+		if peak > 0 {
+			levelDB := 20 * math.Log10(peak)
+			targetGR = compressorCurve(levelDB, config) - levelDB
+		}
+
+		// Attack when reduction increases, release when it recovers
+		if targetGR < smoothedGR {
+			smoothedGR = attackCoeff*smoothedGR + (1-attackCoeff)*targetGR
+		} else {
+			smoothedGR = releaseCoeff*smoothedGR + (1-releaseCoeff)*targetGR
+		}
+
+		gain := math.Pow(10, smoothedGR/20.0) * makeup
+		for ch := 0; ch < channels; ch++ {
+			samples[frame*channels+ch] *= gain
+		}
+
+		if smoothedGR < -0.1 {
+			totalGR += -smoothedGR
+			compressingFrames++
+		}
+		result.MaxGainReductionDB = math.Max(result.MaxGainReductionDB, -smoothedGR)
+	}
+
+	if compressingFrames > 0 {
+		result.AvgGainReductionDB = totalGR / float64(compressingFrames)
+	}
+	if frameCount > 0 {
+		result.CompressingRatio = float64(compressingFrames) / float64(frameCount)
+	}
+
+	result.ClippedSamples = audioData.FromFloat(samples)
+	return result, nil
+}
+
+// compressorCurve returns the static output level in dB for an input level in dB
+func compressorCurve(levelDB float64, config CompressorConfig) float64 {
+	overshoot := levelDB - config.ThresholdDB
+	knee := config.KneeDB
+
+	switch {
+	case 2*overshoot < -knee:
+		return levelDB
+	case knee > 0 && 2*math.Abs(overshoot) <= knee:
+		x := overshoot + knee/2
+		return levelDB + (1/config.Ratio-1)*x*x/(2*knee)
+	default:
+		return config.ThresholdDB + overshoot/config.Ratio
+	}
+}
+
+// timeCoefficient returns the one-pole smoothing coefficient for a time constant in ms
+func timeCoefficient(timeMs, sampleRate float64) float64 {
+	return math.Exp(-1.0 / (timeMs / 1000.0 * sampleRate))
+}
+
+// CompressMultipleAudio compresses each track with its own settings.
+// A nil entry in configs leaves the corresponding track untouched.
+func CompressMultipleAudio(audioFiles []*audio.AudioData, configs []*CompressorConfig) ([]*CompressionResult, error) {
+	if len(audioFiles) == 0 {
+		return nil, fmt.Errorf("no audio files provided")
+	}
+
+	if len(configs) != len(audioFiles) {
+		return nil, fmt.Errorf("expected %d compressor settings, got %d", len(audioFiles), len(configs))
+	}
+
+	results := make([]*CompressionResult, len(audioFiles))
+	for i, audioData := range audioFiles {
+		if configs[i] == nil {
+			continue
+		}
+
+		result, err := Compress(audioData, *configs[i])
+		if err != nil {
+			return nil, fmt.Errorf("failed to compress %s: %w", audioData.Filename, err)
+		}
+		results[i] = result
+	}
+
+	return results, nil
+}
+
+// Print displays compression results
+func (cr *CompressionResult) Print() {
+	fmt.Printf("Compression: %s\n", cr.Filename)
+	fmt.Printf("  Max Gain Reduction: %.1f dB\n", cr.MaxGainReductionDB)
+	fmt.Printf("  Avg Gain Reduction: %.1f dB (%.1f%% of the time)\n", cr.AvgGainReductionDB, cr.CompressingRatio*100)
+	fmt.Printf("  Makeup Gain: %.1f dB\n", cr.MakeupDB)
+}
+
+// PrintCompressionSummary displays a summary of all compression results.
+// Tracks without a result were not compressed.
+func PrintCompressionSummary(audioFiles []*audio.AudioData, results []*CompressionResult) {
+	fmt.Printf("\nCompression Summary:\n")
+
+	for i, result := range results {
+		if result == nil {
+			fmt.Printf("[%d] %s: bypassed\n", i+1, audioFiles[i].Filename)
+			continue
+		}
+
+		fmt.Printf("[%d] %s: max GR %.1f dB, avg GR %.1f dB (%.1f%% of the time), makeup %+.1f dB",
+			i+1, result.Filename, result.MaxGainReductionDB, result.AvgGainReductionDB,
+			result.CompressingRatio*100, result.MakeupDB)
+
+		if result.ClippedSamples > 0 {
+			fmt.Printf(" ⚠️  %d samples clipped", result.ClippedSamples)
+		} else {
+			fmt.Printf(" ✓")
+		}
+		fmt.Println()
+	}
+}
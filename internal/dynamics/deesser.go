@@ -0,0 +1,194 @@
+package dynamics
+
+import (
+	"fmt"
+	"math"
+
+	"void-cutter/internal/audio"
+	"void-cutter/internal/filter"
+)
+
+// DeEsserConfig holds parameters for the de-esser
+type DeEsserConfig struct {
+	ThresholdDB float64 // Sibilance band level above which it is reduced, in dBFS
+	ReductionDB float64 // Maximum reduction of the sibilance band
+	LowHz       float64 // Lower edge of the sibilance band
+	HighHz      float64 // Upper edge of the sibilance band
+	AttackMs    float64
+	ReleaseMs   float64
+}
+
+// DeEsserResult contains the results of de-essing
+type DeEsserResult struct {
+	MaxReductionDB float64 // Largest band reduction applied
+	AvgReductionDB float64 // Average band reduction while de-essing
+	ActiveRatio    float64 // Fraction of the track where the band was reduced
+	ClippedSamples int
+	Filename       string
+}
+
+// DefaultDeEsserConfig returns default de-esser settings for voice tracks
+func DefaultDeEsserConfig() DeEsserConfig {
+	return DeEsserConfig{
+		ThresholdDB: -30.0,
+		ReductionDB: 6.0,
+		LowHz:       5000.0,
+		HighHz:      9000.0,
+		AttackMs:    1.0,
+		ReleaseMs:   60.0,
+	}
+}
+
+// Validate checks if the de-esser settings are usable
+func (c DeEsserConfig) Validate() error {
+	if c.ThresholdDB > 0 || c.ThresholdDB < -80 {
+		return fmt.Errorf("de-esser threshold must be between -80 and 0 dBFS")
+	}
+
+	if c.ReductionDB <= 0 || c.ReductionDB > 40 {
+		return fmt.Errorf("de-esser reduction must be between 0 and 40 dB")
+	}
+
+	if c.LowHz <= 0 || c.HighHz <= c.LowHz {
+		return fmt.Errorf("de-esser band must satisfy 0 < low < high")
+	}
+
+	if c.AttackMs <= 0 || c.ReleaseMs <= 0 {
+		return fmt.Errorf("de-esser attack and release must be positive")
+	}
+
+	return nil
+}
+
+// DeEss reduces sibilance in audio data in place. The sibilance band is split
+// off with a band-pass filter, its linked peak level drives the gain, and only
+// the band is attenuated (the signal is unchanged while below the threshold).
+func DeEss(audioData *audio.AudioData, config DeEsserConfig) (*DeEsserResult, error) {
+	if audioData == nil {
+		return nil, fmt.Errorf("audio data is nil")
+	}
+
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	sampleRate := float64(audioData.SampleRate)
+	if config.HighHz >= sampleRate/2 {
+		return nil, fmt.Errorf("de-esser band must be below %.0f Hz", sampleRate/2)
+	}
+
+	channels := audioData.Channels
+	frameCount := audioData.GetFrameCount()
+
+	// Band split per channel, centered geometrically on the band
+	center := math.Sqrt(config.LowHz * config.HighHz)
+	q := center / (config.HighHz - config.LowHz)
+	bands := make([]*filter.Biquad, channels)
+	for ch := range bands {
+		bands[ch] = filter.NewBandPass(center, q, sampleRate)
+	}
+
+	attackCoeff := timeCoefficient(config.AttackMs, sampleRate)
+	releaseCoeff := timeCoefficient(config.ReleaseMs, sampleRate)
+
+	samples := audioData.ToFloat()
+	result := &DeEsserResult{Filename: audioData.Filename}
+	band := make([]float64, channels)
+	envelope := 0.0
+	var totalReduction float64
+	activeFrames := 0
+
+	for frame := 0; frame < frameCount; frame++ {
+		peak := 0.0
+		for ch := 0; ch < channels; ch++ {
+			band[ch] = bands[ch].Process(samples[frame*channels+ch])
+			peak = math.Max(peak, math.Abs(band[ch]))
+		}
+
+		if peak > envelope {
+			envelope = attackCoeff*envelope + (1-attackCoeff)*peak
+		} else {
+			envelope = releaseCoeff*envelope + (1-releaseCoeff)*peak
+		}
+
+		reductionDB := 0.0
+		if envelope > 0 {
+			reductionDB = math.Min(config.ReductionDB, 20*math.Log10(envelope)-config.ThresholdDB)
+		}
+		if reductionDB <= 0 {
+			continue
+		}
+
+		// Replace the band with its attenuated copy
+		bandGain := math.Pow(10, -reductionDB/20.0)
+		for ch := 0; ch < channels; ch++ {
+			samples[frame*channels+ch] -= band[ch] * (1 - bandGain)
+		}
+
+		if reductionDB > 0.1 {
+			totalReduction += reductionDB
+			activeFrames++
+		}
+		result.MaxReductionDB = math.Max(result.MaxReductionDB, reductionDB)
+	}
+
+	if activeFrames > 0 {
+		result.AvgReductionDB = totalReduction / float64(activeFrames)
+	}
+	if frameCount > 0 {
+		result.ActiveRatio = float64(activeFrames) / float64(frameCount)
+	}
+
+	result.ClippedSamples = audioData.FromFloat(samples)
+	return result, nil
+}
+
+// DeEssMultipleAudio de-esses each track with its own settings.
+// A nil entry in configs leaves the corresponding track untouched.
+func DeEssMultipleAudio(audioFiles []*audio.AudioData, configs []*DeEsserConfig) ([]*DeEsserResult, error) {
+	if len(audioFiles) == 0 {
+		return nil, fmt.Errorf("no audio files provided")
+	}
+
+	if len(configs) != len(audioFiles) {
+		return nil, fmt.Errorf("expected %d de-esser settings, got %d", len(audioFiles), len(configs))
+	}
+
+	results := make([]*DeEsserResult, len(audioFiles))
+	for i, audioData := range audioFiles {
+		if configs[i] == nil {
+			continue
+		}
+
+		result, err := DeEss(audioData, *configs[i])
+		if err != nil {
+			return nil, fmt.Errorf("failed to de-ess %s: %w", audioData.Filename, err)
+		}
+		results[i] = result
+	}
+
+	return results, nil
+}
+
+// PrintDeEsserSummary displays a summary of all de-esser results.
+// Tracks without a result were not de-essed.
+func PrintDeEsserSummary(audioFiles []*audio.AudioData, results []*DeEsserResult) {
+	fmt.Printf("\nDe-Esser Summary:\n")
+
+	for i, result := range results {
+		if result == nil {
+			fmt.Printf("[%d] %s: bypassed\n", i+1, audioFiles[i].Filename)
+			continue
+		}
+
+		fmt.Printf("[%d] %s: max reduction %.1f dB, avg %.1f dB (%.1f%% of the time)",
+			i+1, result.Filename, result.MaxReductionDB, result.AvgReductionDB, result.ActiveRatio*100)
+
+		if result.ClippedSamples > 0 {
+			fmt.Printf(" ⚠️  %d samples clipped", result.ClippedSamples)
+		} else {
+			fmt.Printf(" ✓")
+		}
+		fmt.Println()
+	}
+}
@@ -0,0 +1,229 @@
+package dynamics
+
+import (
+	"fmt"
+	"math"
+
+	"void-cutter/internal/audio"
+	"void-cutter/pkg/detector"
+)
+
+const gateChunkMs = 10 // Gate decision resolution in milliseconds
+
+// GateConfig holds parameters for the noise gate / downward expander
+type GateConfig struct {
+	ThresholdDB      float64 // Level at which the gate opens, in dBFS
+	HysteresisDB     float64 // The gate closes this far below the threshold
+	HoldMs           float64 // Time the gate stays open after the level drops
+	RangeDB          float64 // Maximum attenuation when closed
+	Ratio            float64 // Expansion ratio below the threshold (0 for a hard gate)
+	AttackMs         float64 // Time to open
+	ReleaseMs        float64 // Time to close
+	RelativeMarginDB float64 // Also close when this far below the loudest track (0 disables)
+}
+
+// GateResult contains the results of gating
+type GateResult struct {
+	OpenRatio          float64 // Fraction of the track where the gate was open
+	AvgAttenuationDB   float64 // Average attenuation while closed
+	MaxAttenuationDB   float64 // Largest attenuation applied
+	RelativeCloseRatio float64 // Fraction closed because another track was louder
+	Filename           string
+}
+
+// DefaultGateConfig returns default gate settings for speaker tracks
+func DefaultGateConfig() GateConfig {
+	return GateConfig{
+		ThresholdDB:  -45.0,
+		HysteresisDB: 6.0,
+		HoldMs:       150.0,
+		RangeDB:      20.0,
+		Ratio:        0.0,
+		AttackMs:     2.0,
+		ReleaseMs:    100.0,
+	}
+}
+
+// Validate checks if the gate settings are usable
+func (c GateConfig) Validate() error {
+	if c.ThresholdDB > 0 || c.ThresholdDB < -120 {
+		return fmt.Errorf("gate threshold must be between -120 and 0 dBFS")
+	}
+
+	if c.HysteresisDB < 0 || c.HoldMs < 0 || c.RangeDB < 0 || c.RelativeMarginDB < 0 {
+		return fmt.Errorf("gate hysteresis, hold, range and relative margin must be non-negative")
+	}
+
+	if c.Ratio != 0 && c.Ratio < 1 {
+		return fmt.Errorf("gate ratio must be 0 (gate) or at least 1 (expander)")
+	}
+
+	if c.AttackMs <= 0 || c.ReleaseMs <= 0 {
+		return fmt.Errorf("gate attack and release must be positive")
+	}
+
+	return nil
+}
+
+// GateMultipleAudio gates each track with its own settings. A nil entry in
+// configs leaves the corresponding track untouched, but every track still
+// counts as a reference for relative (cross-track) decisions.
+func GateMultipleAudio(audioFiles []*audio.AudioData, configs []*GateConfig) ([]*GateResult, error) {
+	if len(audioFiles) == 0 {
+		return nil, fmt.Errorf("no audio files provided")
+	}
+
+	if len(configs) != len(audioFiles) {
+		return nil, fmt.Errorf("expected %d gate settings, got %d", len(audioFiles), len(configs))
+	}
+
+	// Per-chunk levels of every track for the open/close decisions
+	levels := make([][]float64, len(audioFiles))
+	var chunkFrames int
+	for i, audioData := range audioFiles {
+		chunkFrames, levels[i] = detector.MeasureLevels(audioData, detector.Config{ChunkSizeMs: gateChunkMs})
+	}
+
+	// Loudest track per chunk
+	var loudest []float64
+	for _, trackLevels := range levels {
+		for chunk, level := range trackLevels {
+			if chunk >= len(loudest) {
+				loudest = append(loudest, math.Inf(-1))
+			}
+			loudest[chunk] = math.Max(loudest[chunk], level)
+		}
+	}
+
+	results := make([]*GateResult, len(audioFiles))
+	for i, audioData := range audioFiles {
+		if configs[i] == nil {
+			continue
+		}
+
+		if err := configs[i].Validate(); err != nil {
+			return nil, fmt.Errorf("failed to gate %s: %w", audioData.Filename, err)
+		}
+
+		results[i] = applyGate(audioData, levels[i], loudest, chunkFrames, *configs[i])
+	}
+
+	return results, nil
+}
+
+// applyGate decides the gate state per chunk and applies the smoothed gain in place
+func applyGate(audioData *audio.AudioData, levels, loudest []float64, chunkFrames int, config GateConfig) *GateResult {
+	chunkMs := float64(chunkFrames) / float64(audioData.SampleRate) * 1000
+	holdChunks := int(math.Ceil(config.HoldMs / chunkMs))
+	closeThreshold := config.ThresholdDB - config.HysteresisDB
+	relative := config.RelativeMarginDB > 0
+
+	result := &GateResult{Filename: audioData.Filename}
+
+	// Target attenuation per chunk (0 when open, negative dB when closed)
+	targets := make([]float64, len(levels))
+	open := false
+	holdLeft := 0
+	openChunks, relativeChunks, closedChunks := 0, 0, 0
+	var totalAttenuation float64
+
+	for chunk, level := range levels {
+		aboveOpen := level > config.ThresholdDB
+		belowClose := level < closeThreshold
+		dominated := false
+		if relative {
+			dominated = level < loudest[chunk]-config.RelativeMarginDB
+			aboveOpen = aboveOpen && !dominated
+			belowClose = belowClose || level < loudest[chunk]-config.RelativeMarginDB-config.HysteresisDB
+		}
+
+		switch {
+		case aboveOpen:
+			open = true
+			holdLeft = holdChunks
+		case open && belowClose:
+			if holdLeft > 0 {
+				holdLeft--
+			} else {
+				open = false
+			}
+		}
+
+		if open {
+			openChunks++
+			continue
+		}
+
+		attenuation := config.RangeDB
+		if config.Ratio >= 1 && !math.IsInf(level, -1) && !dominated {
+			// Downward expansion proportional to the distance below the threshold
+			attenuation = math.Min(config.RangeDB, (config.ThresholdDB-level)*(config.Ratio-1))
+		}
+		targets[chunk] = -attenuation
+
+		closedChunks++
+		totalAttenuation += attenuation
+		if dominated {
+			relativeChunks++
+		}
+	}
+
+	if len(levels) > 0 {
+		result.OpenRatio = float64(openChunks) / float64(len(levels))
+		result.RelativeCloseRatio = float64(relativeChunks) / float64(len(levels))
+	}
+	if closedChunks > 0 {
+		result.AvgAttenuationDB = totalAttenuation / float64(closedChunks)
+	}
+
+	// Smooth the chunk targets per frame; look one chunk ahead so that onsets open in time
+	sampleRate := float64(audioData.SampleRate)
+	attackCoeff := timeCoefficient(config.AttackMs, sampleRate)
+	releaseCoeff := timeCoefficient(config.ReleaseMs, sampleRate)
+	channels := audioData.Channels
+
+	samples := audioData.ToFloat()
+	gainDB := 0.0
+	for frame := 0; frame < audioData.GetFrameCount(); frame++ {
+		chunk := frame / chunkFrames
+		target := targets[min(chunk, len(targets)-1)]
+		if chunk+1 < len(targets) {
+			target = math.Max(target, targets[chunk+1])
+		}
+
+		if target > gainDB {
+			gainDB = attackCoeff*gainDB + (1-attackCoeff)*target
+		} else {
+			gainDB = releaseCoeff*gainDB + (1-releaseCoeff)*target
+		}
+		result.MaxAttenuationDB = math.Max(result.MaxAttenuationDB, -gainDB)
+
+		gain := math.Pow(10, gainDB/20.0)
+		for ch := 0; ch < channels; ch++ {
+			samples[frame*channels+ch] *= gain
+		}
+	}
+
+	audioData.FromFloat(samples)
+	return result
+}
+
+// PrintGateSummary displays a summary of all gate results.
+// Tracks without a result were not gated.
+func PrintGateSummary(audioFiles []*audio.AudioData, results []*GateResult) {
+	fmt.Printf("\nNoise Gate Summary:\n")
+
+	for i, result := range results {
+		if result == nil {
+			fmt.Printf("[%d] %s: bypassed\n", i+1, audioFiles[i].Filename)
+			continue
+		}
+
+		fmt.Printf("[%d] %s: open %.1f%% of the time, avg attenuation %.1f dB (max %.1f dB)",
+			i+1, result.Filename, result.OpenRatio*100, result.AvgAttenuationDB, result.MaxAttenuationDB)
+		if result.RelativeCloseRatio > 0 {
+			fmt.Printf(", %.1f%% closed by a louder track", result.RelativeCloseRatio*100)
+		}
+		fmt.Println()
+	}
+}
@@ -0,0 +1,101 @@
+package filter
+
+import "math"
+
+// Biquad is a second-order IIR filter (transposed direct form II).
+// Coefficients follow the RBJ Audio EQ Cookbook.
+type Biquad struct {
+	b0, b1, b2, a1, a2 float64
+	z1, z2             float64
+}
+
+// newBiquad normalizes cookbook coefficients by a0
+func newBiquad(b0, b1, b2, a0, a1, a2 float64) *Biquad {
+	return &Biquad{
+		b0: b0 / a0,
+		b1: b1 / a0,
+		b2: b2 / a0,
+		a1: a1 / a0,
+		a2: a2 / a0,
+	}
+}
+
+// cookbook returns the common intermediate values for a filter design
+func cookbook(frequency, q, sampleRate float64) (cosW0, alpha float64) {
+	w0 := 2 * math.Pi * frequency / sampleRate
+	return math.Cos(w0), math.Sin(w0) / (2 * q)
+}
+
+// NewHighPass creates a high-pass filter
+func NewHighPass(frequency, q, sampleRate float64) *Biquad {
+	cosW0, alpha := cookbook(frequency, q, sampleRate)
+	return newBiquad((1+cosW0)/2, -(1 + cosW0), (1+cosW0)/2, 1+alpha, -2*cosW0, 1-alpha)
+}
+
+// NewLowPass creates a low-pass filter
+func NewLowPass(frequency, q, sampleRate float64) *Biquad {
+	cosW0, alpha := cookbook(frequency, q, sampleRate)
+	return newBiquad((1-cosW0)/2, 1-cosW0, (1-cosW0)/2, 1+alpha, -2*cosW0, 1-alpha)
+}
+
+// NewBandPass creates a band-pass filter with 0 dB peak gain
+func NewBandPass(frequency, q, sampleRate float64) *Biquad {
+	cosW0, alpha := cookbook(frequency, q, sampleRate)
+	return newBiquad(alpha, 0, -alpha, 1+alpha, -2*cosW0, 1-alpha)
+}
+
+// NewNotch creates a notch (band-stop) filter
+func NewNotch(frequency, q, sampleRate float64) *Biquad {
+	cosW0, alpha := cookbook(frequency, q, sampleRate)
+	return newBiquad(1, -2*cosW0, 1, 1+alpha, -2*cosW0, 1-alpha)
+}
+
+// NewPeaking creates a peaking (bell) EQ filter
+func NewPeaking(frequency, gainDB, q, sampleRate float64) *Biquad {
+	cosW0, alpha := cookbook(frequency, q, sampleRate)
+	a := math.Pow(10, gainDB/40)
+	return newBiquad(1+alpha*a, -2*cosW0, 1-alpha*a, 1+alpha/a, -2*cosW0, 1-alpha/a)
+}
+
+// NewLowShelf creates a low-shelf EQ filter
+func NewLowShelf(frequency, gainDB, q, sampleRate float64) *Biquad {
+	cosW0, alpha := cookbook(frequency, q, sampleRate)
+	a := math.Pow(10, gainDB/40)
+	sqrtA := 2 * math.Sqrt(a) * alpha
+	return newBiquad(
+		a*((a+1)-(a-1)*cosW0+sqrtA),
+		2*a*((a-1)-(a+1)*cosW0),
+		a*((a+1)-(a-1)*cosW0-sqrtA),
+		(a+1)+(a-1)*cosW0+sqrtA,
+		-2*((a-1)+(a+1)*cosW0),
+		(a+1)+(a-1)*cosW0-sqrtA,
+	)
+}
+
+// NewHighShelf creates a high-shelf EQ filter
+func NewHighShelf(frequency, gainDB, q, sampleRate float64) *Biquad {
+	cosW0, alpha := cookbook(frequency, q, sampleRate)
+	a := math.Pow(10, gainDB/40)
+	sqrtA := 2 * math.Sqrt(a) * alpha
+	return newBiquad(
+		a*((a+1)+(a-1)*cosW0+sqrtA),
+		-2*a*((a-1)+(a+1)*cosW0),
+		a*((a+1)+(a-1)*cosW0-sqrtA),
+		(a+1)-(a-1)*cosW0+sqrtA,
+		2*((a-1)-(a+1)*cosW0),
+		(a+1)-(a-1)*cosW0-sqrtA,
+	)
+}
+
+// Process filters a single sample
+func (f *Biquad) Process(x float64) float64 {
+	y := f.b0*x + f.z1
+	f.z1 = f.b1*x - f.a1*y + f.z2
+	f.z2 = f.b2*x - f.a2*y
+	return y
+}
+
+// Reset clears the filter state
+func (f *Biquad) Reset() {
+	f.z1, f.z2 = 0, 0
+}
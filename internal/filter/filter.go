@@ -0,0 +1,275 @@
+package filter
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"void-cutter/internal/audio"
+)
+
+// Filter types
+const (
+	TypeHighPass  = "hpf"
+	TypeLowPass   = "lpf"
+	TypeLowShelf  = "lowshelf"
+	TypeHighShelf = "highshelf"
+	TypePeaking   = "peak"
+	TypeNotch     = "notch"
+	TypeBandPass  = "bandpass"
+)
+
+// FilterSpec describes a single filter in a chain
+type FilterSpec struct {
+	Type        string
+	FrequencyHz float64
+	GainDB      float64 // Shelf and peaking filters only
+	Q           float64
+}
+
+// FilterResult contains the results of filtering
+type FilterResult struct {
+	Filters        []FilterSpec
+	LevelBeforeDB  float64 // RMS level before filtering in dBFS
+	LevelAfterDB   float64 // RMS level after filtering in dBFS
+	ClippedSamples int
+	Filename       string
+}
+
+// defaultQ returns the default Q for a filter type
+func defaultQ(filterType string) float64 {
+	switch filterType {
+	case TypePeaking:
+		return 1.0
+	case TypeNotch:
+		return 30.0
+	default:
+		return math.Sqrt2 / 2 // Butterworth
+	}
+}
+
+// DefaultChain returns the default filter chain: an 80 Hz high-pass against rumble
+func DefaultChain() []FilterSpec {
+	return []FilterSpec{{Type: TypeHighPass, FrequencyHz: 80, Q: defaultQ(TypeHighPass)}}
+}
+
+// ParseFilterChain parses a filter chain such as "hpf:80,lowshelf:200:-3,peak:3000:2:1.4".
+// Each filter is "type:frequency[:gain][:q]"; gain applies to shelf and peaking filters only.
+func ParseFilterChain(spec string) ([]FilterSpec, error) {
+	var chain []FilterSpec
+
+	for _, item := range strings.Split(spec, ",") {
+		parts := strings.Split(strings.TrimSpace(item), ":")
+		filterType := strings.ToLower(parts[0])
+
+		var values []float64
+		for _, part := range parts[1:] {
+			value, err := strconv.ParseFloat(strings.TrimSpace(part), 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid value %q in filter %q", part, item)
+			}
+			values = append(values, value)
+		}
+
+		hasGain := false
+		switch filterType {
+		case TypeLowShelf, TypeHighShelf, TypePeaking:
+			hasGain = true
+		case TypeHighPass, TypeLowPass, TypeNotch, TypeBandPass:
+		default:
+			return nil, fmt.Errorf("unknown filter type %q (available: hpf, lpf, lowshelf, highshelf, peak, notch, bandpass)", parts[0])
+		}
+
+		required, maximum := 1, 2
+		if hasGain {
+			required, maximum = 2, 3
+		}
+		if len(values) < required || len(values) > maximum {
+			return nil, fmt.Errorf("filter %q expects %d to %d values", item, required, maximum)
+		}
+
+		filterSpec := FilterSpec{
+			Type:        filterType,
+			FrequencyHz: values[0],
+			Q:           defaultQ(filterType),
+		}
+		if hasGain {
+			filterSpec.GainDB = values[1]
+		}
+		if len(values) == maximum {
+			filterSpec.Q = values[maximum-1]
+		}
+
+		if err := filterSpec.check(); err != nil {
+			return nil, err
+		}
+		chain = append(chain, filterSpec)
+	}
+
+	return chain, nil
+}
+
+// Validate checks if the filter can be designed for the sample rate
+func (fs FilterSpec) Validate(sampleRate int) error {
+	if err := fs.check(); err != nil {
+		return err
+	}
+
+	if nyquist := float64(sampleRate) / 2; fs.FrequencyHz >= nyquist {
+		return fmt.Errorf("%s frequency %.1f Hz must be between 0 and %.0f Hz", fs.Type, fs.FrequencyHz, nyquist)
+	}
+
+	return nil
+}
+
+// check validates the parts of the filter that do not depend on the sample
+// rate, so that a bad chain is rejected before any audio is loaded
+func (fs FilterSpec) check() error {
+	if fs.FrequencyHz <= 0 {
+		return fmt.Errorf("%s frequency %.1f Hz must be positive", fs.Type, fs.FrequencyHz)
+	}
+
+	if fs.Q <= 0 {
+		return fmt.Errorf("%s Q must be positive", fs.Type)
+	}
+
+	return nil
+}
+
+// New creates the biquad described by the spec
+func (fs FilterSpec) New(sampleRate int) *Biquad {
+	sr := float64(sampleRate)
+	switch fs.Type {
+	case TypeHighPass:
+		return NewHighPass(fs.FrequencyHz, fs.Q, sr)
+	case TypeLowPass:
+		return NewLowPass(fs.FrequencyHz, fs.Q, sr)
+	case TypeLowShelf:
+		return NewLowShelf(fs.FrequencyHz, fs.GainDB, fs.Q, sr)
+	case TypeHighShelf:
+		return NewHighShelf(fs.FrequencyHz, fs.GainDB, fs.Q, sr)
+	case TypePeaking:
+		return NewPeaking(fs.FrequencyHz, fs.GainDB, fs.Q, sr)
+	case TypeNotch:
+		return NewNotch(fs.FrequencyHz, fs.Q, sr)
+	default:
+		return NewBandPass(fs.FrequencyHz, fs.Q, sr)
+	}
+}
+
+// String formats the spec in the same syntax ParseFilterChain accepts
+func (fs FilterSpec) String() string {
+	switch fs.Type {
+	case TypeLowShelf, TypeHighShelf, TypePeaking:
+		return fmt.Sprintf("%s:%g:%g:%.2g", fs.Type, fs.FrequencyHz, fs.GainDB, fs.Q)
+	default:
+		return fmt.Sprintf("%s:%g:%.2g", fs.Type, fs.FrequencyHz, fs.Q)
+	}
+}
+
+// ApplyToSamples runs a filter chain over normalized interleaved samples in place
+func ApplyToSamples(samples []float64, channels, sampleRate int, chain []FilterSpec) {
+	for ch := 0; ch < channels; ch++ {
+		for _, spec := range chain {
+			biquad := spec.New(sampleRate)
+			for i := ch; i < len(samples); i += channels {
+				samples[i] = biquad.Process(samples[i])
+			}
+		}
+	}
+}
+
+// ApplyFilters runs a filter chain over audio data in place
+func ApplyFilters(audioData *audio.AudioData, chain []FilterSpec) (*FilterResult, error) {
+	if audioData == nil {
+		return nil, fmt.Errorf("audio data is nil")
+	}
+
+	for _, spec := range chain {
+		if err := spec.Validate(audioData.SampleRate); err != nil {
+			return nil, err
+		}
+	}
+
+	samples := audioData.ToFloat()
+	levelBefore := rmsDB(samples)
+
+	ApplyToSamples(samples, audioData.Channels, audioData.SampleRate, chain)
+
+	return &FilterResult{
+		Filters:        chain,
+		LevelBeforeDB:  levelBefore,
+		LevelAfterDB:   rmsDB(samples),
+		ClippedSamples: audioData.FromFloat(samples),
+		Filename:       audioData.Filename,
+	}, nil
+}
+
+// rmsDB returns the RMS level of normalized samples in dBFS
+func rmsDB(samples []float64) float64 {
+	if len(samples) == 0 {
+		return math.Inf(-1)
+	}
+
+	var sumSquares float64
+	for _, sample := range samples {
+		sumSquares += sample * sample
+	}
+	return 10 * math.Log10(sumSquares/float64(len(samples)))
+}
+
+// FilterMultipleAudio filters each track with its own chain.
+// An empty chain leaves the corresponding track untouched.
+func FilterMultipleAudio(audioFiles []*audio.AudioData, chains [][]FilterSpec) ([]*FilterResult, error) {
+	if len(audioFiles) == 0 {
+		return nil, fmt.Errorf("no audio files provided")
+	}
+
+	if len(chains) != len(audioFiles) {
+		return nil, fmt.Errorf("expected %d filter chains, got %d", len(audioFiles), len(chains))
+	}
+
+	results := make([]*FilterResult, len(audioFiles))
+	for i, audioData := range audioFiles {
+		if len(chains[i]) == 0 {
+			continue
+		}
+
+		result, err := ApplyFilters(audioData, chains[i])
+		if err != nil {
+			return nil, fmt.Errorf("failed to filter %s: %w", audioData.Filename, err)
+		}
+		results[i] = result
+	}
+
+	return results, nil
+}
+
+// PrintFilterSummary displays a summary of all filter results.
+// Tracks without a result were not filtered.
+func PrintFilterSummary(audioFiles []*audio.AudioData, results []*FilterResult) {
+	fmt.Printf("\nFilter Summary:\n")
+
+	for i, result := range results {
+		if result == nil {
+			fmt.Printf("[%d] %s: bypassed\n", i+1, audioFiles[i].Filename)
+			continue
+		}
+
+		names := make([]string, len(result.Filters))
+		for j, spec := range result.Filters {
+			names[j] = spec.String()
+		}
+
+		fmt.Printf("[%d] %s: %s (RMS %.1f → %.1f dBFS)",
+			i+1, result.Filename, strings.Join(names, " → "), result.LevelBeforeDB, result.LevelAfterDB)
+
+		if result.ClippedSamples > 0 {
+			fmt.Printf(" ⚠️  %d samples clipped", result.ClippedSamples)
+		} else {
+			fmt.Printf(" ✓")
+		}
+		fmt.Println()
+	}
+}
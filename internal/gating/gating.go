@@ -0,0 +1,109 @@
+package gating
+
+import "math"
+
+const (
+	BlockMs            = 400   // Gating block length (ITU-R BS.1770)
+	HopMs              = 100   // Gating block hop (75% overlap)
+	AbsoluteGateLUFS   = -70.0 // Blocks below this are never counted
+	RelativeGateOffset = -10.0 // Relative gate below the absolute-gated loudness in LU
+	LUFSOffset         = -0.691
+)
+
+// Loudness computes the gated loudness of block mean squares: blocks below the
+// absolute gate are dropped, then blocks 10 LU below the mean of the rest.
+// Returns -Inf if no block passes the gates.
+func Loudness(blockPowers []float64) float64 {
+	gated := RelativeGate(AbsoluteGate(blockPowers), RelativeGateOffset)
+	if len(gated) == 0 {
+		return math.Inf(-1)
+	}
+	return PowerToLUFS(Mean(gated))
+}
+
+// AbsoluteGate returns the block powers above the absolute gate
+func AbsoluteGate(blockPowers []float64) []float64 {
+	var gated []float64
+	for _, power := range blockPowers {
+		if PowerToLUFS(power) > AbsoluteGateLUFS {
+			gated = append(gated, power)
+		}
+	}
+	return gated
+}
+
+// RelativeGate returns the block powers above the loudness of their mean plus offsetLU
+func RelativeGate(blockPowers []float64, offsetLU float64) []float64 {
+	if len(blockPowers) == 0 {
+		return nil
+	}
+
+	gate := PowerToLUFS(Mean(blockPowers)) + offsetLU
+	var gated []float64
+	for _, power := range blockPowers {
+		if PowerToLUFS(power) > gate {
+			gated = append(gated, power)
+		}
+	}
+	return gated
+}
+
+// BlockMeanSquares returns the mean square of each analysis block of normalized
+// interleaved samples. Audio shorter than one block is measured as a single block.
+func BlockMeanSquares(samples []float64, channels, sampleRate, blockMs, hopMs int) []float64 {
+	frames := len(samples) / channels
+	blockFrames := blockMs * sampleRate / 1000
+	hopFrames := hopMs * sampleRate / 1000
+	if hopFrames == 0 {
+		hopFrames = 1
+	}
+	if blockFrames > frames || blockFrames == 0 {
+		blockFrames = frames
+	}
+
+	// Slide the block by one hop at a time: drop the frames that leave it and add
+	// the ones that enter, so long blocks (e.g. 3s for LRA) are not re-summed per hop
+	var powers []float64
+	var sumSquares float64
+	for start := 0; start+blockFrames <= frames && blockFrames > 0; start += hopFrames {
+		if start == 0 || hopFrames >= blockFrames {
+			sumSquares = sumOfSquares(samples[start*channels : (start+blockFrames)*channels])
+		} else {
+			previous := start - hopFrames
+			sumSquares -= sumOfSquares(samples[previous*channels : start*channels])
+			sumSquares += sumOfSquares(samples[(previous+blockFrames)*channels : (start+blockFrames)*channels])
+		}
+		powers = append(powers, math.Max(sumSquares, 0)/float64(blockFrames*channels))
+	}
+	return powers
+}
+
+// sumOfSquares returns the sum of the squared samples
+func sumOfSquares(samples []float64) float64 {
+	var sum float64
+	for _, sample := range samples {
+		sum += sample * sample
+	}
+	return sum
+}
+
+// PowerToLUFS converts a mean square value to approximate LUFS (-Inf for zero power)
+func PowerToLUFS(power float64) float64 {
+	if power <= 0 {
+		return math.Inf(-1)
+	}
+	return 10*math.Log10(power) + LUFSOffset
+}
+
+// Mean returns the arithmetic mean of values (0 when empty)
+func Mean(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	return sum / float64(len(values))
+}
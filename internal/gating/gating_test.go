@@ -0,0 +1,77 @@
+package gating
+
+import (
+	"math"
+	"testing"
+)
+
+const testSampleRate = 48000
+
+// tone returns seconds of a 1 kHz sine whose mean square reads lufs
+func tone(seconds, lufs float64) []float64 {
+	amplitude := math.Sqrt(2 * lufsToPower(lufs))
+	samples := make([]float64, int(seconds*testSampleRate))
+	for i := range samples {
+		samples[i] = amplitude * math.Sin(2*math.Pi*1000*float64(i)/testSampleRate)
+	}
+	return samples
+}
+
+func lufsToPower(lufs float64) float64 {
+	return math.Pow(10, (lufs-LUFSOffset)/10)
+}
+
+func TestBlockMeanSquares(t *testing.T) {
+	// 100ms of tone followed by silence: only the first 400ms block sees it,
+	// at a quarter of its power, and the next block starts 100ms later
+	samples := append(tone(0.1, -20), make([]float64, int(1.9*testSampleRate))...)
+	powers := BlockMeanSquares(samples, 1, testSampleRate, BlockMs, HopMs)
+
+	if want := 17; len(powers) != want {
+		t.Fatalf("got %d blocks, want %d", len(powers), want)
+	}
+	if got, want := PowerToLUFS(powers[0]), -20+10*math.Log10(0.25); math.Abs(got-want) > 0.01 {
+		t.Errorf("first block %.2f LUFS, want %.2f", got, want)
+	}
+	for i, power := range powers[1:] {
+		if power > 1e-12 {
+			t.Errorf("block %d has power %g, want silence", i+1, power)
+		}
+	}
+}
+
+func TestLoudness(t *testing.T) {
+	tests := []struct {
+		name     string
+		segments [][2]float64 // Tone segments as seconds and LUFS
+		want     float64
+	}{
+		{"steady tone", [][2]float64{{2, -23}}, -23},
+		{"quiet blocks above -70 LUFS count", [][2]float64{{2, -65}}, -65},
+		{"absolute gate drops blocks below -70 LUFS", [][2]float64{{2, -75}}, math.Inf(-1)},
+		{"relative gate drops blocks 10 LU below", [][2]float64{{4, -20}, {4, -35}}, -20},
+		{"relative gate keeps blocks within 10 LU", [][2]float64{{4, -20}, {4, -28}}, -20 + 10*math.Log10((1+math.Pow(10, -0.8))/2)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Measure each segment on its own so that no block straddles
+			// two levels and blurs the expected value
+			var blocks []float64
+			for _, segment := range tt.segments {
+				blocks = append(blocks, BlockMeanSquares(tone(segment[0], segment[1]), 1, testSampleRate, BlockMs, HopMs)...)
+			}
+
+			got := Loudness(blocks)
+			if math.IsInf(tt.want, -1) {
+				if !math.IsInf(got, -1) {
+					t.Errorf("got %.2f LUFS, want -Inf", got)
+				}
+				return
+			}
+			if math.Abs(got-tt.want) > 0.01 {
+				t.Errorf("got %.2f LUFS, want %.2f", got, tt.want)
+			}
+		})
+	}
+}
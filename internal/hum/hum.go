@@ -0,0 +1,387 @@
+package hum
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"void-cutter/internal/audio"
+	"void-cutter/internal/filter"
+	"void-cutter/internal/silence"
+	"void-cutter/internal/spectral"
+)
+
+const (
+	minProfileFrames = 4     // Frames needed for a spectrum from quiet regions
+	fallbackQuantile = 0.10  // Quietest fraction of frames used when silence is too short
+	baselineInnerHz  = 4.0   // Baseline excludes this distance around a harmonic
+	baselineOuterHz  = 15.0  // Baseline extends this far from a harmonic
+	mainsTolerance   = 0.005 // Relative deviation of the mains frequency
+	powerFloor       = 1e-30
+)
+
+// Mains frequencies considered by the detector
+var mainsFrequencies = []float64{50, 60}
+
+// HumConfig holds parameters for hum detection and removal
+type HumConfig struct {
+	FundamentalHz   float64 // Mains frequency (50 or 60), 0 to detect automatically
+	Harmonics       int     // Number of harmonics to examine, including the fundamental
+	Q               float64 // Notch Q at the fundamental (scaled per harmonic for constant bandwidth)
+	MinProminenceDB float64 // A harmonic must stand out this far above its neighbourhood
+}
+
+// HumResult contains the results of hum detection and removal
+type HumResult struct {
+	Detected      bool
+	FundamentalHz float64   // Estimated mains frequency
+	Harmonics     []float64 // Notched frequencies in Hz
+	HumLevelDB    float64   // Level of the hum components before removal in dBFS
+	ReductionDB   float64   // Reduction of the hum components in the analyzed frames
+	FromSilence   bool      // Spectrum taken from detected silence (false: quietest frames)
+	Filename      string
+}
+
+// DefaultHumConfig returns default hum removal settings
+func DefaultHumConfig() HumConfig {
+	return HumConfig{
+		FundamentalHz:   0,
+		Harmonics:       8,
+		Q:               30.0,
+		MinProminenceDB: 10.0,
+	}
+}
+
+// Validate checks if the hum removal settings are usable
+func (c HumConfig) Validate() error {
+	if c.FundamentalHz != 0 && c.FundamentalHz != 50 && c.FundamentalHz != 60 {
+		return fmt.Errorf("hum fundamental must be 50, 60 or 0 (auto)")
+	}
+
+	if c.Harmonics < 1 || c.Harmonics > 40 {
+		return fmt.Errorf("hum harmonics must be between 1 and 40")
+	}
+
+	if c.Q <= 0 {
+		return fmt.Errorf("hum notch Q must be positive")
+	}
+
+	if c.MinProminenceDB <= 0 {
+		return fmt.Errorf("hum prominence must be positive")
+	}
+
+	return nil
+}
+
+// analyzer averages long Hann-windowed spectra of the mono sum of a track
+type analyzer struct {
+	fftSize    int
+	sampleRate int
+	window     []float64
+}
+
+// newAnalyzer uses frames of about 1/4 second (under 3 Hz resolution at common rates)
+func newAnalyzer(sampleRate int) *analyzer {
+	fftSize := spectral.NextPowerOfTwo(sampleRate / 4)
+	return &analyzer{
+		fftSize:    fftSize,
+		sampleRate: sampleRate,
+		window:     spectral.HannWindow(fftSize),
+	}
+}
+
+// frameStarts returns the analysis frames (half overlap) that lie entirely inside
+// the regions, or the quietest frames of the signal if the regions provide too few
+func (a *analyzer) frameStarts(mono []float64, regions []silence.SilenceRegion) ([]int, bool) {
+	hop := a.fftSize / 2
+
+	var starts []int
+	for _, region := range regions {
+		for start := region.StartFrame; start+a.fftSize <= min(region.EndFrame, len(mono)); start += hop {
+			starts = append(starts, start)
+		}
+	}
+	if len(starts) >= minProfileFrames {
+		return starts, true
+	}
+
+	// Quietest frames, so that voiced speech does not pose as hum harmonics
+	type frameEnergy struct {
+		start  int
+		energy float64
+	}
+	var energies []frameEnergy
+	for start := 0; start+a.fftSize <= len(mono); start += hop {
+		var energy float64
+		for _, sample := range mono[start : start+a.fftSize] {
+			energy += sample * sample
+		}
+		energies = append(energies, frameEnergy{start, energy})
+	}
+	sort.Slice(energies, func(i, j int) bool { return energies[i].energy < energies[j].energy })
+
+	count := max(int(float64(len(energies))*fallbackQuantile), min(minProfileFrames, len(energies)))
+	starts = starts[:0]
+	for _, fe := range energies[:count] {
+		starts = append(starts, fe.start)
+	}
+	return starts, false
+}
+
+// spectrum returns the average power spectrum of the mono signal over the frames
+func (a *analyzer) spectrum(mono []float64, starts []int) []float64 {
+	power := make([]float64, a.fftSize/2+1)
+	if len(starts) == 0 {
+		return power
+	}
+
+	buffer := make([]complex128, a.fftSize)
+	for _, start := range starts {
+		for i := range buffer {
+			buffer[i] = complex(mono[start+i]*a.window[i], 0)
+		}
+		spectral.FFT(buffer)
+		for bin, p := range spectral.PowerSpectrum(buffer) {
+			power[bin] += p
+		}
+	}
+	for bin := range power {
+		power[bin] /= float64(len(starts))
+	}
+	return power
+}
+
+// harmonic describes a spectral peak near a multiple of the mains frequency
+type harmonic struct {
+	frequency    float64 // Interpolated peak frequency
+	peakPower    float64
+	baseline     float64
+	prominenceDB float64
+	bin          int
+}
+
+// findHarmonic locates the peak near a target frequency and measures how far it
+// stands out from the median of the surrounding bins
+func (a *analyzer) findHarmonic(power []float64, target float64) harmonic {
+	binHz := float64(a.sampleRate) / float64(a.fftSize)
+	center := spectral.FrequencyBin(target, a.fftSize, a.sampleRate)
+	reach := max(1, int(math.Ceil(target*mainsTolerance/binHz)))
+
+	peak := center
+	for bin := center - reach; bin <= center+reach; bin++ {
+		if bin > 0 && bin < len(power)-1 && power[bin] > power[peak] {
+			peak = bin
+		}
+	}
+
+	var neighbours []float64
+	inner := int(math.Ceil(baselineInnerHz / binHz))
+	outer := int(math.Ceil(baselineOuterHz / binHz))
+	for offset := inner; offset <= outer; offset++ {
+		if peak-offset > 0 {
+			neighbours = append(neighbours, power[peak-offset])
+		}
+		if peak+offset < len(power) {
+			neighbours = append(neighbours, power[peak+offset])
+		}
+	}
+	sort.Float64s(neighbours)
+	baseline := powerFloor
+	if len(neighbours) > 0 {
+		baseline = math.Max(neighbours[len(neighbours)/2], powerFloor)
+	}
+
+	// Parabolic interpolation of the peak on a log scale
+	frequency := spectral.BinFrequency(peak, a.fftSize, a.sampleRate)
+	if peak > 0 && peak < len(power)-1 {
+		l := math.Log(math.Max(power[peak-1], powerFloor))
+		c := math.Log(math.Max(power[peak], powerFloor))
+		r := math.Log(math.Max(power[peak+1], powerFloor))
+		if denominator := l - 2*c + r; denominator < 0 {
+			frequency += 0.5 * (l - r) / denominator * binHz
+		}
+	}
+
+	return harmonic{
+		frequency:    frequency,
+		peakPower:    power[peak],
+		baseline:     baseline,
+		prominenceDB: 10 * math.Log10(math.Max(power[peak], powerFloor)/baseline),
+		bin:          peak,
+	}
+}
+
+// detect finds the mains frequency whose harmonics stand out the most.
+// Returns the fundamental and the prominent harmonics (empty if no hum was found).
+func (a *analyzer) detect(power []float64, config HumConfig) (float64, []harmonic) {
+	candidates := mainsFrequencies
+	if config.FundamentalHz > 0 {
+		candidates = []float64{config.FundamentalHz}
+	}
+
+	nyquist := float64(a.sampleRate) / 2
+	bestScore, bestFundamental := 0.0, 0.0
+	var best []harmonic
+
+	for _, fundamental := range candidates {
+		var found []harmonic
+		var score float64
+		for k := 1; k <= config.Harmonics && float64(k)*fundamental+baselineOuterHz < nyquist; k++ {
+			h := a.findHarmonic(power, float64(k)*fundamental)
+			if h.prominenceDB >= config.MinProminenceDB {
+				found = append(found, h)
+				score += h.prominenceDB
+			}
+		}
+
+		if score > bestScore {
+			bestScore, bestFundamental, best = score, fundamental, found
+		}
+	}
+
+	if len(best) == 0 {
+		return 0, nil
+	}
+
+	// Refine the fundamental from the harmonic peaks, weighted by their excess power
+	var weighted, weights float64
+	for _, h := range best {
+		weight := math.Max(h.peakPower-h.baseline, 0)
+		weighted += weight * h.frequency / math.Round(h.frequency/bestFundamental)
+		weights += weight
+	}
+	if weights == 0 {
+		return bestFundamental, best
+	}
+	return weighted / weights, best
+}
+
+// RemoveHum detects mains hum in the quiet regions of a track and removes the
+// fundamental and its harmonics with a cascade of narrow notch filters in place
+func RemoveHum(audioData *audio.AudioData, regions []silence.SilenceRegion, config HumConfig) (*HumResult, error) {
+	if audioData == nil {
+		return nil, fmt.Errorf("audio data is nil")
+	}
+
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	channels := audioData.Channels
+	samples := audioData.ToFloat()
+	result := &HumResult{Filename: audioData.Filename}
+
+	mono := make([]float64, len(samples)/channels)
+	for i := range mono {
+		for ch := 0; ch < channels; ch++ {
+			mono[i] += samples[i*channels+ch]
+		}
+		mono[i] /= float64(channels)
+	}
+
+	a := newAnalyzer(audioData.SampleRate)
+	starts, fromSilence := a.frameStarts(mono, regions)
+	result.FromSilence = fromSilence
+
+	power := a.spectrum(mono, starts)
+	fundamental, harmonics := a.detect(power, config)
+	if len(harmonics) == 0 {
+		return result, nil
+	}
+
+	result.Detected = true
+	result.FundamentalHz = fundamental
+
+	// Hann-windowed sinusoid of amplitude A peaks at |X| = A*N/4
+	var humPower, beforePower float64
+	var chain []filter.FilterSpec
+	for _, h := range harmonics {
+		k := math.Round(h.frequency / fundamental)
+		frequency := k * fundamental
+		result.Harmonics = append(result.Harmonics, frequency)
+		chain = append(chain, filter.FilterSpec{
+			Type:        filter.TypeNotch,
+			FrequencyHz: frequency,
+			Q:           config.Q * k,
+		})
+
+		amplitude := 4 * math.Sqrt(math.Max(h.peakPower-h.baseline, 0)) / float64(a.fftSize)
+		humPower += amplitude * amplitude / 2
+		beforePower += h.peakPower
+	}
+	result.HumLevelDB = 10 * math.Log10(math.Max(humPower, powerFloor))
+
+	filter.ApplyToSamples(samples, channels, audioData.SampleRate, chain)
+	audioData.FromFloat(samples)
+
+	// Measure the same bins in the same frames after filtering
+	for i := range mono {
+		mono[i] = 0
+		for ch := 0; ch < channels; ch++ {
+			mono[i] += samples[i*channels+ch]
+		}
+		mono[i] /= float64(channels)
+	}
+	after := a.spectrum(mono, starts)
+	var afterPower float64
+	for _, h := range harmonics {
+		afterPower += after[h.bin]
+	}
+	result.ReductionDB = 10 * math.Log10(beforePower/math.Max(afterPower, powerFloor))
+
+	return result, nil
+}
+
+// RemoveHumMultipleAudio removes hum from each track with its own settings.
+// A nil entry in configs leaves the corresponding track untouched.
+func RemoveHumMultipleAudio(audioFiles []*audio.AudioData, regions []silence.SilenceRegion, configs []*HumConfig) ([]*HumResult, error) {
+	if len(audioFiles) == 0 {
+		return nil, fmt.Errorf("no audio files provided")
+	}
+
+	if len(configs) != len(audioFiles) {
+		return nil, fmt.Errorf("expected %d hum removal settings, got %d", len(audioFiles), len(configs))
+	}
+
+	results := make([]*HumResult, len(audioFiles))
+	for i, audioData := range audioFiles {
+		if configs[i] == nil {
+			continue
+		}
+
+		result, err := RemoveHum(audioData, regions, *configs[i])
+		if err != nil {
+			return nil, fmt.Errorf("failed to remove hum from %s: %w", audioData.Filename, err)
+		}
+		results[i] = result
+	}
+
+	return results, nil
+}
+
+// PrintHumSummary displays a summary of all hum removal results.
+// Tracks without a result were not processed.
+func PrintHumSummary(audioFiles []*audio.AudioData, results []*HumResult) {
+	fmt.Printf("\nHum Removal Summary:\n")
+
+	for i, result := range results {
+		if result == nil {
+			fmt.Printf("[%d] %s: bypassed\n", i+1, audioFiles[i].Filename)
+			continue
+		}
+
+		source := "detected silence"
+		if !result.FromSilence {
+			source = "quietest frames"
+		}
+
+		if !result.Detected {
+			fmt.Printf("[%d] %s: no hum detected (analyzed %s) ✓\n", i+1, result.Filename, source)
+			continue
+		}
+
+		fmt.Printf("[%d] %s: %.2f Hz hum at %.1f dBFS, %d notches (%.0f–%.0f Hz), reduced by %.1f dB (analyzed %s)\n",
+			i+1, result.Filename, result.FundamentalHz, result.HumLevelDB, len(result.Harmonics),
+			result.Harmonics[0], result.Harmonics[len(result.Harmonics)-1], result.ReductionDB, source)
+	}
+}
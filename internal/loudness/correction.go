@@ -0,0 +1,154 @@
+package loudness
+
+import (
+	"fmt"
+	"math"
+
+	"void-cutter/internal/audio"
+)
+
+// CorrectionResult contains the results of a post-processing loudness correction
+type CorrectionResult struct {
+	BeforeLoudness float64 // Loudness measured before correction
+	AfterLoudness  float64 // Loudness measured after correction
+	TargetLoudness float64
+	GainDB         float64 // Corrective gain (0 if within tolerance)
+	Corrected      bool
+	Skipped        bool   // Left untouched by a per-track override
+	Filename       string // Track filename, or "program" in mix mode
+}
+
+// CorrectLoudness re-measures already normalized audio (e.g. after silence cutting)
+// and applies a corrective gain where the loudness drifted more than toleranceLU
+// from the target. In mix and linked modes the summed program is measured and one
+// gain is applied to every track; otherwise each track is corrected on its own.
+// The gated loudness of the delivered audio is measured even with SpeechGated set.
+func CorrectLoudness(audioFiles []*audio.AudioData, config NormalizationConfig, toleranceLU float64) ([]*CorrectionResult, error) {
+	if len(audioFiles) == 0 {
+		return nil, fmt.Errorf("no audio files provided")
+	}
+
+	if config.Mode == ModeMix || config.Mode == ModeLinked {
+		result, err := correctProgram(audioFiles, config, toleranceLU)
+		if err != nil {
+			return nil, err
+		}
+		return []*CorrectionResult{result}, nil
+	}
+
+	results := make([]*CorrectionResult, len(audioFiles))
+	for i, audioData := range audioFiles {
+		target, skip := config.trackTarget(i)
+		if skip {
+			results[i] = &CorrectionResult{Skipped: true, Filename: audioData.Filename}
+			continue
+		}
+
+		// Speech-gated loudness hardly changes when silence is cut, so the
+		// delivered audio is measured as a whole, like the program in mix mode
+		measurement, err := MeasureLoudness(audioData)
+		if err != nil {
+			return nil, fmt.Errorf("failed to measure loudness for %s: %w", audioData.Filename, err)
+		}
+
+		result := &CorrectionResult{
+			BeforeLoudness: measurement.IntegratedLoudness,
+			AfterLoudness:  measurement.IntegratedLoudness,
+			TargetLoudness: target,
+			Filename:       audioData.Filename,
+		}
+
+		gainDB := target - measurement.IntegratedLoudness
+		if math.Abs(gainDB) > toleranceLU && !math.IsInf(gainDB, 0) {
+			// Never push the peak over the ceiling with a corrective boost
+			if headroom := config.TruePeakCeiling - measurement.TruePeak; gainDB > 0 && gainDB > headroom {
+				gainDB = math.Max(headroom, 0)
+			}
+
+			audioData.ApplyGain(math.Pow(10, gainDB/20.0))
+			result.GainDB = gainDB
+			result.Corrected = gainDB != 0
+			result.AfterLoudness = measurement.IntegratedLoudness + gainDB
+		}
+
+		results[i] = result
+	}
+
+	return results, nil
+}
+
+// correctProgram corrects the loudness of the summed program with one shared gain.
+// Skipped tracks are neither measured nor corrected.
+func correctProgram(audioFiles []*audio.AudioData, config NormalizationConfig, toleranceLU float64) (*CorrectionResult, error) {
+	programGains := config.programGains(len(audioFiles))
+	before, err := measureProgram(audioFiles, programGains)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &CorrectionResult{
+		BeforeLoudness: before,
+		AfterLoudness:  before,
+		TargetLoudness: config.TargetLUFS,
+		Filename:       "program",
+	}
+
+	gainDB := config.TargetLUFS - before
+	if math.Abs(gainDB) <= toleranceLU || math.IsInf(gainDB, 0) {
+		return result, nil
+	}
+
+	// Never push any track's peak over the ceiling with a corrective boost
+	for i, audioData := range audioFiles {
+		if math.IsInf(programGains[i], -1) {
+			continue
+		}
+		peak := 20 * math.Log10(calculateTruePeakWithBitDepth(audioData.Samples, audioData.BitDepth))
+		if headroom := config.TruePeakCeiling - peak; gainDB > 0 && gainDB > headroom {
+			gainDB = math.Max(headroom, 0)
+		}
+	}
+
+	gain := math.Pow(10, gainDB/20.0)
+	for i, audioData := range audioFiles {
+		if !math.IsInf(programGains[i], -1) {
+			audioData.ApplyGain(gain)
+		}
+	}
+
+	after, err := measureProgram(audioFiles, programGains)
+	if err != nil {
+		return nil, err
+	}
+
+	result.GainDB = gainDB
+	result.Corrected = gainDB != 0
+	result.AfterLoudness = after
+	return result, nil
+}
+
+// PrintCorrectionSummary displays a summary of all loudness corrections
+func PrintCorrectionSummary(results []*CorrectionResult, toleranceLU float64) {
+	fmt.Printf("\nPost-Cut Loudness Correction Summary:\n")
+	fmt.Printf("Tolerance: ±%.1f LU\n", toleranceLU)
+
+	corrected := 0
+	for i, result := range results {
+		if result.Skipped {
+			fmt.Printf("[%d] %s: skipped\n", i+1, result.Filename)
+			continue
+		}
+
+		fmt.Printf("[%d] %s: %.1f → %.1f LUFS (target %.1f)", i+1, result.Filename, result.BeforeLoudness, result.AfterLoudness, result.TargetLoudness)
+
+		if result.Corrected {
+			fmt.Printf(" (%+.1f dB)", result.GainDB)
+			corrected++
+		} else {
+			fmt.Printf(" ✓ within tolerance")
+		}
+		fmt.Println()
+	}
+
+	fmt.Printf("\n%d of %d measurement(s) corrected\n", corrected, len(results))
+}
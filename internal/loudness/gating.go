@@ -0,0 +1,29 @@
+package loudness
+
+import (
+	"fmt"
+
+	"void-cutter/internal/audio"
+	"void-cutter/internal/gating"
+)
+
+// MeasureGatedLoudness measures loudness over 400ms blocks, ignoring blocks below
+// an absolute gate (-70 LUFS) and a relative gate (10 LU below the gated mean),
+// so that long pauses do not pull the measurement down
+func MeasureGatedLoudness(audioData *audio.AudioData) (float64, error) {
+	if audioData == nil {
+		return 0, fmt.Errorf("audio data is nil")
+	}
+
+	if len(audioData.Samples) == 0 {
+		return 0, fmt.Errorf("no audio samples found")
+	}
+
+	return gatedLoudness(audioData.ToFloat(), audioData.Channels, audioData.SampleRate), nil
+}
+
+// gatedLoudness computes block-gated loudness of normalized interleaved samples.
+// Returns -Inf if no block passes the gates.
+func gatedLoudness(samples []float64, channels, sampleRate int) float64 {
+	return gating.Loudness(gating.BlockMeanSquares(samples, channels, sampleRate, gating.BlockMs, gating.HopMs))
+}
@@ -0,0 +1,215 @@
+package loudness
+
+import (
+	"fmt"
+	"math"
+
+	"void-cutter/internal/audio"
+	"void-cutter/internal/gating"
+	"void-cutter/internal/silence"
+	"void-cutter/pkg/detector"
+)
+
+// LevelerConfig holds parameters for the speech leveler (slow automatic gain riding)
+type LevelerConfig struct {
+	TargetLUFS float64 // Short-term loudness the leveler rides towards
+	MaxBoostDB float64 // Maximum gain increase
+	MaxCutDB   float64 // Maximum gain reduction
+	SpeedSec   float64 // Time constant of gain changes in seconds (larger is slower)
+	WindowMs   int     // Short-term loudness window in milliseconds
+
+	// Activity detection; gain is frozen while the track is silent
+	Silence silence.SilenceDetectionConfig
+}
+
+// LevelerResult contains the results of speech leveling
+type LevelerResult struct {
+	MinGainDB      float64 // Lowest gain applied during speech
+	MaxGainDB      float64 // Highest gain applied during speech
+	AvgGainDB      float64 // Average gain applied during speech
+	ClippedSamples int
+	Filename       string
+}
+
+// DefaultLevelerConfig returns default speech leveler configuration
+func DefaultLevelerConfig() LevelerConfig {
+	return LevelerConfig{
+		TargetLUFS: -16.0,
+		MaxBoostDB: 6.0,
+		MaxCutDB:   6.0,
+		SpeedSec:   2.0,
+		WindowMs:   3000,
+		Silence:    silence.DefaultSilenceConfig(),
+	}
+}
+
+// LevelAudio rides the gain of a track towards a target short-term loudness during
+// active speech. Gain changes are smoothed with the configured time constant and
+// held while the track is silent, so that noise in pauses is not pumped up.
+func LevelAudio(audioData *audio.AudioData, config LevelerConfig) (*LevelerResult, error) {
+	if audioData == nil {
+		return nil, fmt.Errorf("audio data is nil")
+	}
+
+	if config.MaxBoostDB < 0 || config.MaxCutDB < 0 {
+		return nil, fmt.Errorf("leveler max boost and max cut must be non-negative")
+	}
+
+	if config.SpeedSec <= 0 {
+		return nil, fmt.Errorf("leveler speed must be positive")
+	}
+
+	activity, err := silence.DetectActivity(audioData, config.Silence)
+	if err != nil {
+		return nil, fmt.Errorf("failed to detect speech activity: %w", err)
+	}
+
+	chunkGainsDB := levelerGainCurve(activity, audioData.SampleRate, config)
+
+	result := &LevelerResult{
+		MinGainDB: math.Inf(1),
+		MaxGainDB: math.Inf(-1),
+		Filename:  audioData.Filename,
+	}
+
+	activeChunks := 0
+	for i, gainDB := range chunkGainsDB {
+		if !activity.Active[i] {
+			continue
+		}
+		result.MinGainDB = math.Min(result.MinGainDB, gainDB)
+		result.MaxGainDB = math.Max(result.MaxGainDB, gainDB)
+		result.AvgGainDB += gainDB
+		activeChunks++
+	}
+
+	if activeChunks == 0 {
+		result.MinGainDB, result.MaxGainDB = 0, 0
+		return result, nil
+	}
+	result.AvgGainDB /= float64(activeChunks)
+
+	// Interpolate the chunk gains between chunk centers and apply per frame
+	chunkGains := make([]float64, len(chunkGainsDB))
+	for i, gainDB := range chunkGainsDB {
+		chunkGains[i] = math.Pow(10, gainDB/20.0)
+	}
+
+	samples := audioData.ToFloat()
+	channels := audioData.Channels
+	chunkFrames := activity.ChunkFrames
+	for frame := 0; frame < audioData.GetFrameCount(); frame++ {
+		position := (float64(frame) - float64(chunkFrames)/2) / float64(chunkFrames)
+		chunk := int(math.Floor(position))
+		gain := interpolateGain(chunkGains, chunk, position-float64(chunk))
+		for ch := 0; ch < channels; ch++ {
+			samples[frame*channels+ch] *= gain
+		}
+	}
+
+	result.ClippedSamples = audioData.FromFloat(samples)
+	return result, nil
+}
+
+// levelerGainCurve computes the smoothed leveler gain for every analysis chunk
+func levelerGainCurve(activity *detector.TrackActivity, sampleRate int, config LevelerConfig) []float64 {
+	chunkSec := float64(activity.ChunkFrames) / float64(sampleRate)
+	halfWindow := int(float64(config.WindowMs) / 1000.0 / chunkSec / 2)
+	alpha := 1 - math.Exp(-chunkSec/config.SpeedSec)
+
+	// Prefix sums of active chunk power for the sliding short-term window
+	powerSums := make([]float64, len(activity.Active)+1)
+	countSums := make([]int, len(activity.Active)+1)
+	for i, active := range activity.Active {
+		powerSums[i+1] = powerSums[i]
+		countSums[i+1] = countSums[i]
+		if active {
+			powerSums[i+1] += math.Pow(10, activity.LevelsDB[i]/10)
+			countSums[i+1]++
+		}
+	}
+
+	// Short-term loudness over the active chunks around each chunk
+	desired := make([]float64, len(activity.Active))
+	hasDesired := make([]bool, len(activity.Active))
+	for i := range activity.Active {
+		start := max(i-halfWindow, 0)
+		end := min(i+halfWindow+1, len(activity.Active))
+		count := countSums[end] - countSums[start]
+		if count == 0 {
+			continue
+		}
+
+		shortTerm := gating.PowerToLUFS((powerSums[end] - powerSums[start]) / float64(count))
+		gainDB := config.TargetLUFS - shortTerm
+		desired[i] = math.Max(-config.MaxCutDB, math.Min(config.MaxBoostDB, gainDB))
+		hasDesired[i] = true
+	}
+
+	// Start from the first speech gain instead of ramping in from unity
+	current := 0.0
+	for i := range desired {
+		if activity.Active[i] && hasDesired[i] {
+			current = desired[i]
+			break
+		}
+	}
+
+	gains := make([]float64, len(desired))
+	for i := range desired {
+		// Freeze during silence
+		if activity.Active[i] && hasDesired[i] {
+			current += (desired[i] - current) * alpha
+		}
+		gains[i] = current
+	}
+	return gains
+}
+
+// interpolateGain linearly interpolates between neighbouring chunk gains
+func interpolateGain(gains []float64, chunk int, fraction float64) float64 {
+	if chunk < 0 {
+		return gains[0]
+	}
+	if chunk >= len(gains)-1 {
+		return gains[len(gains)-1]
+	}
+	return gains[chunk]*(1-fraction) + gains[chunk+1]*fraction
+}
+
+// LevelMultipleAudio applies the speech leveler to multiple audio files
+func LevelMultipleAudio(audioFiles []*audio.AudioData, config LevelerConfig) ([]*LevelerResult, error) {
+	if len(audioFiles) == 0 {
+		return nil, fmt.Errorf("no audio files provided")
+	}
+
+	results := make([]*LevelerResult, len(audioFiles))
+	for i, audioData := range audioFiles {
+		result, err := LevelAudio(audioData, config)
+		if err != nil {
+			return nil, fmt.Errorf("failed to level %s: %w", audioData.Filename, err)
+		}
+		results[i] = result
+	}
+
+	return results, nil
+}
+
+// PrintLevelerSummary displays a summary of all leveler results
+func PrintLevelerSummary(results []*LevelerResult, config LevelerConfig) {
+	fmt.Printf("\nSpeech Leveler Summary:\n")
+	fmt.Printf("Target: %.1f LUFS short-term (max boost %.1f dB, max cut %.1f dB, speed %.1fs)\n",
+		config.TargetLUFS, config.MaxBoostDB, config.MaxCutDB, config.SpeedSec)
+
+	for i, result := range results {
+		fmt.Printf("[%d] %s: gain %+.1f to %+.1f dB (avg %+.1f dB)",
+			i+1, result.Filename, result.MinGainDB, result.MaxGainDB, result.AvgGainDB)
+
+		if result.ClippedSamples > 0 {
+			fmt.Printf(" ⚠️  %d samples clipped", result.ClippedSamples)
+		} else {
+			fmt.Printf(" ✓")
+		}
+		fmt.Println()
+	}
+}
@@ -0,0 +1,148 @@
+package loudness
+
+import (
+	"fmt"
+	"math"
+
+	"void-cutter/internal/audio"
+)
+
+// LinkedNormalizationResult contains the results of linked normalization
+type LinkedNormalizationResult struct {
+	Tracks                []*NormalizationResult // Per-track results
+	ProgramLoudnessBefore float64                // Gated loudness of the summed program
+	ProgramLoudnessAfter  float64
+	GainDB                float64 // Gain shared by all tracks
+	RequestedGainDB       float64 // Gain needed to reach the target
+	PeakLimited           bool    // The gain was capped by the loudest track's true peak
+	LimitingTrack         string  // Track whose true peak capped the gain
+	TargetLoudness        float64
+}
+
+// NormalizeLinked measures the summed program and applies one shared gain to every
+// track, keeping the relative levels of pre-balanced sessions intact. The gain is
+// capped so that the loudest track's true peak stays below the ceiling. Skipped
+// tracks are left untouched and out of the program; gain offsets are added per
+// track, while per-track targets are rejected because they would change the balance.
+func NormalizeLinked(audioFiles []*audio.AudioData, config NormalizationConfig) (*LinkedNormalizationResult, error) {
+	if len(audioFiles) == 0 {
+		return nil, fmt.Errorf("no audio files provided")
+	}
+
+	if err := ValidateTargetLoudness(config.TargetLUFS); err != nil {
+		return nil, fmt.Errorf("invalid target loudness: %w", err)
+	}
+
+	for i, audioData := range audioFiles {
+		if i < len(config.Overrides) && config.Overrides[i] != nil && config.Overrides[i].TargetLUFS != 0 {
+			return nil, fmt.Errorf("per-track target for %s is not supported in %s mode", audioData.Filename, ModeLinked)
+		}
+	}
+
+	// Program with the per-track offsets applied; skipped tracks are left out
+	offsetsDB := config.programGains(len(audioFiles))
+	for i := range offsetsDB {
+		if i < len(config.Overrides) && config.Overrides[i] != nil && !config.Overrides[i].Skip {
+			offsetsDB[i] = config.Overrides[i].GainOffsetDB
+		}
+	}
+
+	programBefore, err := measureProgram(audioFiles, config.programGains(len(audioFiles)))
+	if err != nil {
+		return nil, err
+	}
+
+	programOffset, err := measureProgram(audioFiles, offsetsDB)
+	if err != nil {
+		return nil, err
+	}
+	if math.IsInf(programOffset, -1) {
+		return nil, fmt.Errorf("program is silent; cannot normalize linked tracks")
+	}
+
+	result := &LinkedNormalizationResult{
+		ProgramLoudnessBefore: programBefore,
+		RequestedGainDB:       config.TargetLUFS - programOffset,
+		TargetLoudness:        config.TargetLUFS,
+	}
+	result.GainDB = result.RequestedGainDB
+
+	// Cap by the true peak of the loudest track
+	measurements := make([]*LoudnessResult, len(audioFiles))
+	for i, audioData := range audioFiles {
+		measurement, err := MeasureForNormalization(audioData, config)
+		if err != nil {
+			return nil, fmt.Errorf("failed to measure loudness for %s: %w", audioData.Filename, err)
+		}
+		measurements[i] = measurement
+
+		if math.IsInf(offsetsDB[i], -1) {
+			continue
+		}
+		if headroom := config.TruePeakCeiling - measurement.TruePeak - offsetsDB[i]; result.GainDB > headroom {
+			result.GainDB = headroom
+			result.PeakLimited = true
+			result.LimitingTrack = audioData.Filename
+		}
+	}
+
+	result.Tracks = make([]*NormalizationResult, len(audioFiles))
+	for i, audioData := range audioFiles {
+		if math.IsInf(offsetsDB[i], -1) {
+			result.Tracks[i] = &NormalizationResult{
+				OriginalLoudness: measurements[i].IntegratedLoudness,
+				TargetLoudness:   measurements[i].IntegratedLoudness,
+				AppliedGain:      1.0,
+				Skipped:          true,
+				Filename:         audioData.Filename,
+			}
+			continue
+		}
+
+		gainDB := result.GainDB + offsetsDB[i]
+		gain := math.Pow(10, gainDB/20.0)
+		audioData.ApplyGain(gain)
+
+		result.Tracks[i] = &NormalizationResult{
+			OriginalLoudness: measurements[i].IntegratedLoudness,
+			TargetLoudness:   measurements[i].IntegratedLoudness + gainDB,
+			AppliedGain:      gain,
+			GainDB:           gainDB,
+			ClippingRisk:     measurements[i].TruePeak+result.RequestedGainDB+offsetsDB[i] > config.TruePeakCeiling,
+			Filename:         audioData.Filename,
+		}
+	}
+
+	result.ProgramLoudnessAfter, err = measureProgram(audioFiles, config.programGains(len(audioFiles)))
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// Print displays linked normalization results
+func (lr *LinkedNormalizationResult) Print() {
+	fmt.Printf("\nLinked Normalization Summary:\n")
+	fmt.Printf("Target: %.1f LUFS (program)\n", lr.TargetLoudness)
+
+	for i, track := range lr.Tracks {
+		if track.Skipped {
+			fmt.Printf("[%d] %s: %.1f LUFS, skipped\n", i+1, track.Filename, track.OriginalLoudness)
+			continue
+		}
+
+		fmt.Printf("[%d] %s: %.1f → %.1f LUFS (%+.1f dB)\n",
+			i+1, track.Filename, track.OriginalLoudness, track.TargetLoudness, track.GainDB)
+	}
+
+	fmt.Printf("\nProgram Loudness: %.1f → %.1f LUFS (shared gain %+.1f dB)\n",
+		lr.ProgramLoudnessBefore, lr.ProgramLoudnessAfter, lr.GainDB)
+
+	if lr.PeakLimited {
+		fmt.Printf("⚠️  Gain limited from %+.1f dB by the true peak of %s; the program is %.1f LU below the target\n",
+			lr.RequestedGainDB, lr.LimitingTrack, lr.RequestedGainDB-lr.GainDB)
+	} else {
+		fmt.Printf("✓ Target reached with relative levels preserved\n")
+	}
+}
@@ -13,6 +13,8 @@ type LoudnessResult struct {
 	LoudnessRange      float64 // LU (Loudness Units)
 	TruePeak           float64 // dBFS
 	RMSLevel           float64 // dBFS (Root Mean Square level)
+	SpeechGated        bool    // Measured over active speech only
+	SpeechRatio        float64 // Fraction of the track measured as speech (speech-gated only)
 	Filename           string
 }
 
@@ -137,6 +139,9 @@ func (lr *LoudnessResult) Print() {
 	fmt.Printf("  Integrated Loudness: %.1f LUFS\n", lr.IntegratedLoudness)
 	fmt.Printf("  RMS Level: %.1f dBFS\n", lr.RMSLevel)
 	fmt.Printf("  True Peak: %.1f dBFS\n", lr.TruePeak)
+	if lr.SpeechGated {
+		fmt.Printf("  Speech Activity: %.1f%% (speech-gated)\n", lr.SpeechRatio*100)
+	}
 	if lr.TruePeak > -0.1 {
 		fmt.Printf("  ⚠️  Warning: True peak is close to 0dBFS (risk of clipping)\n")
 	}
@@ -144,11 +149,12 @@ func (lr *LoudnessResult) Print() {
 
 // ValidateTargetLoudness checks if the target loudness is reasonable
 func ValidateTargetLoudness(targetLUFS float64) error {
-	// Common loudness standards:
+	// Common loudness standards are available as presets (see preset.go):
 	// Apple Podcasts: -16 LUFS
 	// Spotify: -14 LUFS
 	// YouTube: -14 LUFS
 	// EBU R128: -23 LUFS (broadcast)
+	// ATSC A/85: -24 LKFS (broadcast)
 
 	if targetLUFS > -6.0 {
 		return fmt.Errorf("target loudness %.1f LUFS is too high (risk of severe clipping)", targetLUFS)
@@ -160,3 +166,16 @@ func ValidateTargetLoudness(targetLUFS float64) error {
 
 	return nil
 }
+
+// ValidateTruePeakCeiling checks if the true-peak ceiling is reasonable
+func ValidateTruePeakCeiling(ceilingDBTP float64) error {
+	if ceilingDBTP > 0.0 {
+		return fmt.Errorf("true-peak ceiling %.1f dBTP is above full scale", ceilingDBTP)
+	}
+
+	if ceilingDBTP < -12.0 {
+		return fmt.Errorf("true-peak ceiling %.1f dBTP is too low", ceilingDBTP)
+	}
+
+	return nil
+}
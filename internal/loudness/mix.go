@@ -0,0 +1,248 @@
+package loudness
+
+import (
+	"fmt"
+	"math"
+
+	"void-cutter/internal/audio"
+)
+
+// Normalization modes
+const (
+	ModeIndependent = "independent" // Normalize every track to the target on its own
+	ModeMix         = "mix"         // Balance tracks, then normalize the summed program
+	ModeLinked      = "linked"      // Apply one shared gain, keeping the relative levels
+)
+
+// MixNormalizationResult contains the results of mix-aware normalization
+type MixNormalizationResult struct {
+	Tracks                []*NormalizationResult // Per-track results
+	ProgramLoudnessBefore float64                // Gated loudness of the unprocessed mix
+	ProgramLoudnessAfter  float64                // Gated loudness of the normalized mix
+	ProgramGainDB         float64                // Gain shared by all tracks after balancing
+	RequestedGainDB       float64                // Program gain needed to reach the target
+	PeakLimited           bool                   // The program gain was capped by a track's true peak
+	LimitingTrack         string                 // Track whose true peak capped the program gain
+	TargetLoudness        float64
+}
+
+// NormalizeMix balances the tracks to the same gated (or speech-gated) loudness and then
+// applies one common gain so that the summed program meets the target loudness.
+// Per-track targets and gain offsets shift a track's balance relative to the others;
+// skipped tracks are left untouched and out of the program measurement, so the
+// delivered sum including them can be louder than the target. With LimitPeaks
+// set the common gain is capped so that no balanced track's true peak goes over
+// the ceiling; otherwise tracks that would go over are only flagged.
+func NormalizeMix(audioFiles []*audio.AudioData, config NormalizationConfig) (*MixNormalizationResult, error) {
+	if len(audioFiles) == 0 {
+		return nil, fmt.Errorf("no audio files provided")
+	}
+
+	if err := ValidateTargetLoudness(config.TargetLUFS); err != nil {
+		return nil, fmt.Errorf("invalid target loudness: %w", err)
+	}
+
+	// Measure each track; gating ignores pauses so that a speaker who barely
+	// talks is balanced by their speech level rather than their average level
+	measurements := make([]*LoudnessResult, len(audioFiles))
+	balanceDB := make([]float64, len(audioFiles))
+	for i, audioData := range audioFiles {
+		measurement, err := MeasureForNormalization(audioData, config)
+		if err != nil {
+			return nil, fmt.Errorf("failed to measure loudness for %s: %w", audioData.Filename, err)
+		}
+		measurements[i] = measurement
+
+		level := measurement.IntegratedLoudness
+		if !config.SpeechGated {
+			level, err = MeasureGatedLoudness(audioData)
+			if err != nil {
+				return nil, fmt.Errorf("failed to measure loudness for %s: %w", audioData.Filename, err)
+			}
+		}
+		target, skip := config.trackTarget(i)
+		switch {
+		case skip:
+			balanceDB[i] = math.Inf(-1)
+		case !math.IsInf(level, 0):
+			balanceDB[i] = target - level
+		}
+	}
+
+	programGains := config.programGains(len(audioFiles))
+	programBefore, err := measureProgram(audioFiles, programGains)
+	if err != nil {
+		return nil, err
+	}
+
+	// One gain for the whole program after balancing
+	programBalanced, err := measureProgram(audioFiles, balanceDB)
+	if err != nil {
+		return nil, err
+	}
+	if math.IsInf(programBalanced, -1) {
+		return nil, fmt.Errorf("program is silent; cannot normalize mix")
+	}
+	requestedGainDB := config.TargetLUFS - programBalanced
+	programGainDB := requestedGainDB
+
+	// Cap by the track with the least headroom after balancing
+	peakLimited, limitingTrack := false, ""
+	for i, audioData := range audioFiles {
+		if !config.LimitPeaks || math.IsInf(balanceDB[i], -1) {
+			continue
+		}
+		if headroom := config.TruePeakCeiling - measurements[i].TruePeak - balanceDB[i]; programGainDB > headroom {
+			programGainDB = headroom
+			peakLimited = true
+			limitingTrack = audioData.Filename
+		}
+	}
+
+	tracks := make([]*NormalizationResult, len(audioFiles))
+	for i, audioData := range audioFiles {
+		if math.IsInf(balanceDB[i], -1) {
+			tracks[i] = &NormalizationResult{
+				OriginalLoudness: measurements[i].IntegratedLoudness,
+				TargetLoudness:   measurements[i].IntegratedLoudness,
+				AppliedGain:      1.0,
+				Skipped:          true,
+				Filename:         audioData.Filename,
+			}
+			continue
+		}
+
+		gainDB := balanceDB[i] + programGainDB
+		gain := math.Pow(10, gainDB/20.0)
+
+		audioData.ApplyGain(gain)
+
+		tracks[i] = &NormalizationResult{
+			OriginalLoudness: measurements[i].IntegratedLoudness,
+			TargetLoudness:   measurements[i].IntegratedLoudness + gainDB,
+			AppliedGain:      gain,
+			GainDB:           gainDB,
+			ClippingRisk:     measurements[i].TruePeak+balanceDB[i]+requestedGainDB > config.TruePeakCeiling,
+			Filename:         audioData.Filename,
+		}
+	}
+
+	programAfter, err := measureProgram(audioFiles, programGains)
+	if err != nil {
+		return nil, err
+	}
+
+	return &MixNormalizationResult{
+		Tracks:                tracks,
+		ProgramLoudnessBefore: programBefore,
+		ProgramLoudnessAfter:  programAfter,
+		ProgramGainDB:         programGainDB,
+		RequestedGainDB:       requestedGainDB,
+		PeakLimited:           peakLimited,
+		LimitingTrack:         limitingTrack,
+		TargetLoudness:        config.TargetLUFS,
+	}, nil
+}
+
+// MeasureProgramLoudness measures the sum of all tracks as delivered, skipped
+// tracks included. Integrated loudness is gated like the program target of the
+// mix and linked modes; the peak is the sample peak of the sum.
+func MeasureProgramLoudness(audioFiles []*audio.AudioData) (*LoudnessResult, error) {
+	if len(audioFiles) == 0 {
+		return nil, fmt.Errorf("no audio files provided")
+	}
+
+	mix, err := mixSamples(audioFiles, make([]float64, len(audioFiles)))
+	if err != nil {
+		return nil, err
+	}
+	if len(mix) == 0 {
+		return nil, fmt.Errorf("no audio samples found")
+	}
+
+	var sumSquares, peak float64
+	for _, sample := range mix {
+		sumSquares += sample * sample
+		peak = math.Max(peak, math.Abs(sample))
+	}
+
+	reference := audioFiles[0]
+	return &LoudnessResult{
+		IntegratedLoudness: gatedLoudness(mix, reference.Channels, reference.SampleRate),
+		TruePeak:           20 * math.Log10(peak),
+		RMSLevel:           10 * math.Log10(sumSquares/float64(len(mix))),
+		Filename:           "program",
+	}, nil
+}
+
+// measureProgram measures the gated loudness of the sum of all tracks with per-track gains in dB
+func measureProgram(audioFiles []*audio.AudioData, gainsDB []float64) (float64, error) {
+	mix, err := mixSamples(audioFiles, gainsDB)
+	if err != nil {
+		return 0, err
+	}
+	reference := audioFiles[0]
+	return gatedLoudness(mix, reference.Channels, reference.SampleRate), nil
+}
+
+// mixSamples sums the normalized samples of all tracks over their common length
+func mixSamples(audioFiles []*audio.AudioData, gainsDB []float64) ([]float64, error) {
+	reference := audioFiles[0]
+	minSamples := len(reference.Samples)
+	for _, audioData := range audioFiles[1:] {
+		if audioData.SampleRate != reference.SampleRate || audioData.Channels != reference.Channels {
+			return nil, fmt.Errorf("all audio files must have the same sample rate and channel count")
+		}
+		if len(audioData.Samples) < minSamples {
+			minSamples = len(audioData.Samples)
+		}
+	}
+
+	mix := make([]float64, minSamples)
+	for i, audioData := range audioFiles {
+		gain := math.Pow(10, gainsDB[i]/20.0) / audioData.FullScale()
+		for j := 0; j < minSamples; j++ {
+			mix[j] += float64(audioData.Samples[j]) * gain
+		}
+	}
+	return mix, nil
+}
+
+// Print displays mix normalization results
+func (mr *MixNormalizationResult) Print() {
+	fmt.Printf("\nMix Normalization Summary:\n")
+	fmt.Printf("Target: %.1f LUFS (program)\n", mr.TargetLoudness)
+
+	totalClippingRisk := 0
+	for i, track := range mr.Tracks {
+		if track.Skipped {
+			fmt.Printf("[%d] %s: %.1f LUFS, skipped\n", i+1, track.Filename, track.OriginalLoudness)
+			continue
+		}
+
+		fmt.Printf("[%d] %s: %.1f → %.1f LUFS (%+.1f dB)",
+			i+1, track.Filename, track.OriginalLoudness, track.TargetLoudness, track.GainDB)
+
+		if track.ClippingRisk {
+			fmt.Printf(" ⚠️")
+			totalClippingRisk++
+		} else {
+			fmt.Printf(" ✓")
+		}
+		fmt.Println()
+	}
+
+	fmt.Printf("\nProgram Loudness: %.1f → %.1f LUFS (program gain %+.1f dB)\n",
+		mr.ProgramLoudnessBefore, mr.ProgramLoudnessAfter, mr.ProgramGainDB)
+
+	if mr.PeakLimited {
+		fmt.Printf("⚠️  Program gain limited from %+.1f dB by the true peak of %s; the program is %.1f LU below the target\n",
+			mr.RequestedGainDB, mr.LimitingTrack, mr.RequestedGainDB-mr.ProgramGainDB)
+	}
+
+	if totalClippingRisk > 0 {
+		fmt.Printf("\n⚠️  %d file(s) have potential clipping risk\n", totalClippingRisk)
+	} else {
+		fmt.Printf("\n✓ All files normalized successfully without clipping risk\n")
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"math"
 
 	"void-cutter/internal/audio"
+	"void-cutter/internal/silence"
 )
 
 // NormalizationResult contains the results of loudness normalization
@@ -13,19 +14,91 @@ type NormalizationResult struct {
 	TargetLoudness   float64
 	AppliedGain      float64
 	GainDB           float64
-	ClippingRisk     bool
+	ClippingRisk     bool // The gain that reaches the target would push the true peak over the ceiling
+	PeakLimited      bool // Gain limited to protect the true peak, so the target was not reached
+	Skipped          bool // Left untouched by a per-track override
 	Filename         string
 }
 
+// NormalizationConfig holds parameters for loudness normalization
+type NormalizationConfig struct {
+	TargetLUFS      float64 // Integrated loudness target
+	TruePeakCeiling float64 // Maximum true peak in dBTP
+	LimitPeaks      bool    // Cap the gain at the ceiling instead of only limiting large boosts
+	Mode            string  // ModeIndependent, ModeMix or ModeLinked
+
+	// Speech gating: measure each track only where it is active
+	SpeechGated bool
+	Silence     silence.SilenceDetectionConfig // Activity detection settings for speech gating
+
+	// Per-track adjustments indexed like the audio files (nil entries use the common target)
+	Overrides []*TrackOverride
+}
+
+// TrackOverride holds per-track adjustments to loudness normalization
+type TrackOverride struct {
+	TargetLUFS   float64 // Track-specific target (0 uses the common target)
+	GainOffsetDB float64 // Added on top of the gain that reaches the target
+	Skip         bool    // Leave the track untouched
+}
+
+// trackTarget returns the effective target of track i, including its gain
+// offset, and whether the track is skipped
+func (c NormalizationConfig) trackTarget(i int) (float64, bool) {
+	if i >= len(c.Overrides) || c.Overrides[i] == nil {
+		return c.TargetLUFS, false
+	}
+
+	override := c.Overrides[i]
+	target := c.TargetLUFS
+	if override.TargetLUFS != 0 {
+		target = override.TargetLUFS
+	}
+	return target + override.GainOffsetDB, override.Skip
+}
+
+// maxUnlimitedGainDB is the largest gain applied when it risks clipping and
+// the true peak ceiling is not enforced
+const maxUnlimitedGainDB = 6.0
+
+// limitGain limits the gain in dB applied to a track with the given true peak.
+// With LimitPeaks set the true peak never exceeds the ceiling; otherwise a
+// gain that would push it over the ceiling is only limited to 6 dB to prevent
+// severe clipping.
+func (c NormalizationConfig) limitGain(gainDB, truePeak float64) float64 {
+	headroom := c.TruePeakCeiling - truePeak
+	if gainDB <= headroom {
+		return gainDB
+	}
+	if c.LimitPeaks {
+		return headroom
+	}
+	return math.Min(gainDB, maxUnlimitedGainDB)
+}
+
+// programGains returns the per-track gains in dB for measuring the program
+// formed by the normalized tracks (skipped tracks are left out)
+func (c NormalizationConfig) programGains(count int) []float64 {
+	gainsDB := make([]float64, count)
+	for i := range gainsDB {
+		if _, skip := c.trackTarget(i); skip {
+			gainsDB[i] = math.Inf(-1)
+		}
+	}
+	return gainsDB
+}
+
 // NormalizeAudio applies loudness normalization to audio data
-func NormalizeAudio(audioData *audio.AudioData, targetLUFS float64) (*NormalizationResult, error) {
+func NormalizeAudio(audioData *audio.AudioData, config NormalizationConfig) (*NormalizationResult, error) {
+	targetLUFS := config.TargetLUFS
+
 	// Validate target loudness
 	if err := ValidateTargetLoudness(targetLUFS); err != nil {
 		return nil, fmt.Errorf("invalid target loudness: %w", err)
 	}
 
 	// Measure current loudness
-	loudnessResult, err := MeasureLoudness(audioData)
+	loudnessResult, err := MeasureForNormalization(audioData, config)
 	if err != nil {
 		return nil, fmt.Errorf("failed to measure loudness: %w", err)
 	}
@@ -34,16 +107,13 @@ func NormalizeAudio(audioData *audio.AudioData, targetLUFS float64) (*Normalizat
 	gain := CalculateGain(loudnessResult.IntegratedLoudness, targetLUFS)
 	gainDB := 20 * math.Log10(gain)
 
-	// Check for potential clipping
-	clippingRisk := false
-	if loudnessResult.TruePeak+gainDB > -0.1 {
-		clippingRisk = true
-		// Reduce gain to prevent severe clipping
-		if gainDB > 6.0 { // If gain is more than 6dB, limit it
-			fmt.Printf("  ⚠️  Limiting gain from %.1f dB to 6.0 dB to prevent severe clipping\n", gainDB)
-			gainDB = 6.0
-			gain = math.Pow(10, gainDB/20.0)
-		}
+	// Check for potential clipping before limiting the gain
+	clippingRisk := loudnessResult.TruePeak+gainDB > config.TruePeakCeiling
+	peakLimited := false
+	if limited := config.limitGain(gainDB, loudnessResult.TruePeak); limited < gainDB {
+		gainDB = limited
+		gain = math.Pow(10, gainDB/20.0)
+		peakLimited = true
 	}
 
 	// Apply gain to audio data
@@ -55,14 +125,17 @@ func NormalizeAudio(audioData *audio.AudioData, targetLUFS float64) (*Normalizat
 		AppliedGain:      gain,
 		GainDB:           gainDB,
 		ClippingRisk:     clippingRisk,
+		PeakLimited:      peakLimited,
 		Filename:         audioData.Filename,
 	}
 
 	return result, nil
 }
 
-// NormalizeMultipleAudio normalizes multiple audio files to the same target loudness
-func NormalizeMultipleAudio(audioFiles []*audio.AudioData, targetLUFS float64) ([]*NormalizationResult, error) {
+// NormalizeMultipleAudio normalizes multiple audio files to the target loudness.
+// Per-track overrides in the config change a track's target, add a gain offset
+// or leave the track untouched.
+func NormalizeMultipleAudio(audioFiles []*audio.AudioData, config NormalizationConfig) ([]*NormalizationResult, error) {
 	if len(audioFiles) == 0 {
 		return nil, fmt.Errorf("no audio files provided")
 	}
@@ -70,7 +143,25 @@ func NormalizeMultipleAudio(audioFiles []*audio.AudioData, targetLUFS float64) (
 	results := make([]*NormalizationResult, len(audioFiles))
 
 	for i, audioData := range audioFiles {
-		result, err := NormalizeAudio(audioData, targetLUFS)
+		target, skip := config.trackTarget(i)
+		if skip {
+			measurement, err := MeasureForNormalization(audioData, config)
+			if err != nil {
+				return nil, fmt.Errorf("failed to measure loudness for %s: %w", audioData.Filename, err)
+			}
+			results[i] = &NormalizationResult{
+				OriginalLoudness: measurement.IntegratedLoudness,
+				TargetLoudness:   measurement.IntegratedLoudness,
+				AppliedGain:      1.0,
+				Skipped:          true,
+				Filename:         audioData.Filename,
+			}
+			continue
+		}
+
+		trackConfig := config
+		trackConfig.TargetLUFS = target
+		result, err := NormalizeAudio(audioData, trackConfig)
 		if err != nil {
 			return nil, fmt.Errorf("failed to normalize %s: %w", audioData.Filename, err)
 		}
@@ -86,6 +177,9 @@ func (nr *NormalizationResult) Print() {
 	fmt.Printf("  Original Loudness: %.1f LUFS\n", nr.OriginalLoudness)
 	fmt.Printf("  Target Loudness: %.1f LUFS\n", nr.TargetLoudness)
 	fmt.Printf("  Applied Gain: %.2f (%.1f dB)\n", nr.AppliedGain, nr.GainDB)
+	if nr.PeakLimited {
+		fmt.Printf("  ⚠️  Gain limited to protect the true peak\n")
+	}
 
 	if nr.ClippingRisk {
 		fmt.Printf("  ⚠️  Warning: Potential clipping detected\n")
@@ -97,12 +191,36 @@ func (nr *NormalizationResult) Print() {
 // PrintNormalizationSummary displays a summary of all normalization results
 func PrintNormalizationSummary(results []*NormalizationResult) {
 	fmt.Printf("\nLoudness Normalization Summary:\n")
-	fmt.Printf("Target: %.1f LUFS\n", results[0].TargetLoudness)
+
+	// Per-track overrides may give the tracks different targets
+	var targets []float64
+	for _, result := range results {
+		if !result.Skipped && (len(targets) == 0 || targets[0] != result.TargetLoudness) {
+			targets = append(targets, result.TargetLoudness)
+		}
+	}
+	switch len(targets) {
+	case 0:
+	case 1:
+		fmt.Printf("Target: %.1f LUFS\n", targets[0])
+	default:
+		fmt.Printf("Target: per track\n")
+	}
 
 	totalClippingRisk := 0
 	for i, result := range results {
+		if result.Skipped {
+			fmt.Printf("[%d] %s: %.1f LUFS, skipped\n", i+1, result.Filename, result.OriginalLoudness)
+			continue
+		}
+
 		fmt.Printf("[%d] %s: %.1f → %.1f LUFS (%.1f dB)",
-			i+1, result.Filename, result.OriginalLoudness, result.TargetLoudness, result.GainDB)
+			i+1, result.Filename, result.OriginalLoudness, result.OriginalLoudness+result.GainDB, result.GainDB)
+
+		if result.PeakLimited {
+			fmt.Printf(" peak-limited, %.1f LU below target",
+				result.TargetLoudness-result.OriginalLoudness-result.GainDB)
+		}
 
 		if result.ClippingRisk {
 			fmt.Printf(" ⚠️")
@@ -0,0 +1,229 @@
+package loudness
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"void-cutter/internal/audio"
+)
+
+// ComplianceRules describes the delivery requirements checked against a measured file
+type ComplianceRules struct {
+	LoudnessTolerance float64 // Allowed deviation from the target in LU (0 disables the check)
+	MaxTruePeak       float64 // Maximum true peak in dBTP
+	CheckRMS          bool    // Check the RMS level range (ACX-style rules)
+	MinRMS            float64 // Minimum RMS level in dBFS (only when CheckRMS is set)
+	MaxRMS            float64 // Maximum RMS level in dBFS (only when CheckRMS is set)
+	MaxLoudnessRange  float64 // Maximum loudness range in LU (0 disables the check)
+	SampleRate        int     // Required sample rate in Hz (0 disables the check)
+	BitDepth          int     // Required bit depth (0 disables the check)
+}
+
+// Preset is a loudness delivery profile for a distribution platform or standard
+type Preset struct {
+	Name            string
+	Description     string
+	TargetLUFS      float64 // Integrated loudness target
+	TruePeakCeiling float64 // Maximum true peak in dBTP
+	Rules           ComplianceRules
+}
+
+// presets holds the built-in delivery profiles keyed by name
+var presets = map[string]*Preset{
+	"apple-podcasts": {
+		Name:            "apple-podcasts",
+		Description:     "Apple Podcasts (-16 LUFS, -1 dBTP)",
+		TargetLUFS:      -16.0,
+		TruePeakCeiling: -1.0,
+		Rules: ComplianceRules{
+			LoudnessTolerance: 1.0,
+			MaxTruePeak:       -1.0,
+		},
+	},
+	"spotify": {
+		Name:            "spotify",
+		Description:     "Spotify (-14 LUFS, -1 dBTP)",
+		TargetLUFS:      -14.0,
+		TruePeakCeiling: -1.0,
+		Rules: ComplianceRules{
+			LoudnessTolerance: 1.0,
+			MaxTruePeak:       -1.0,
+		},
+	},
+	"youtube": {
+		Name:            "youtube",
+		Description:     "YouTube (-14 LUFS, -1 dBTP)",
+		TargetLUFS:      -14.0,
+		TruePeakCeiling: -1.0,
+		Rules: ComplianceRules{
+			LoudnessTolerance: 1.0,
+			MaxTruePeak:       -1.0,
+		},
+	},
+	"ebu-r128": {
+		Name:            "ebu-r128",
+		Description:     "EBU R128 broadcast (-23 LUFS, -1 dBTP)",
+		TargetLUFS:      -23.0,
+		TruePeakCeiling: -1.0,
+		Rules: ComplianceRules{
+			LoudnessTolerance: 0.5,
+			MaxTruePeak:       -1.0,
+		},
+	},
+	"atsc-a85": {
+		Name:            "atsc-a85",
+		Description:     "ATSC A/85 broadcast (-24 LKFS, -2 dBTP)",
+		TargetLUFS:      -24.0,
+		TruePeakCeiling: -2.0,
+		Rules: ComplianceRules{
+			LoudnessTolerance: 2.0,
+			MaxTruePeak:       -2.0,
+		},
+	},
+	"acx": {
+		Name:            "acx",
+		Description:     "Audible ACX (RMS -23 to -18 dBFS, peak -3 dBFS)",
+		TargetLUFS:      -20.0,
+		TruePeakCeiling: -3.0,
+		Rules: ComplianceRules{
+			MaxTruePeak: -3.0,
+			CheckRMS:    true,
+			MinRMS:      -23.0,
+			MaxRMS:      -18.0,
+			SampleRate:  44100,
+		},
+	},
+}
+
+// GetPreset returns the built-in preset with the given name
+func GetPreset(name string) (*Preset, error) {
+	preset, ok := presets[strings.ToLower(name)]
+	if !ok {
+		return nil, fmt.Errorf("unknown preset %q (available: %s)", name, strings.Join(PresetNames(), ", "))
+	}
+
+	if err := ValidateTargetLoudness(preset.TargetLUFS); err != nil {
+		return nil, fmt.Errorf("invalid preset %s: %w", preset.Name, err)
+	}
+
+	return preset, nil
+}
+
+// PresetNames returns the names of all built-in presets in sorted order
+func PresetNames() []string {
+	names := make([]string, 0, len(presets))
+	for name := range presets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ComplianceResult contains the result of checking a file against a preset
+type ComplianceResult struct {
+	Preset        string
+	Violations    []string
+	Measurement   *LoudnessResult
+	RangeMeasured bool // The loudness range was measured (verify only)
+	Filename      string
+}
+
+// Passed reports whether the file met every rule of the preset
+func (cr *ComplianceResult) Passed() bool {
+	return len(cr.Violations) == 0
+}
+
+// Check compares a loudness measurement against the preset's rules
+func (p *Preset) Check(result *LoudnessResult) *ComplianceResult {
+	var violations []string
+	rules := p.Rules
+
+	if rules.LoudnessTolerance > 0 {
+		deviation := result.IntegratedLoudness - p.TargetLUFS
+		if deviation > rules.LoudnessTolerance || deviation < -rules.LoudnessTolerance {
+			violations = append(violations, fmt.Sprintf("integrated loudness %.1f LUFS outside %.1f ± %.1f LU",
+				result.IntegratedLoudness, p.TargetLUFS, rules.LoudnessTolerance))
+		}
+	}
+
+	if result.TruePeak > rules.MaxTruePeak {
+		violations = append(violations, fmt.Sprintf("true peak %.1f dBTP exceeds %.1f dBTP",
+			result.TruePeak, rules.MaxTruePeak))
+	}
+
+	if rules.CheckRMS && (result.RMSLevel < rules.MinRMS || result.RMSLevel > rules.MaxRMS) {
+		violations = append(violations, fmt.Sprintf("RMS level %.1f dBFS outside %.1f to %.1f dBFS",
+			result.RMSLevel, rules.MinRMS, rules.MaxRMS))
+	}
+
+	if rules.MaxLoudnessRange > 0 && result.LoudnessRange > rules.MaxLoudnessRange {
+		violations = append(violations, fmt.Sprintf("loudness range %.1f LU exceeds %.1f LU",
+			result.LoudnessRange, rules.MaxLoudnessRange))
+	}
+
+	return &ComplianceResult{
+		Preset:      p.Name,
+		Violations:  violations,
+		Measurement: result,
+		Filename:    result.Filename,
+	}
+}
+
+// Verify measures audio data and checks it against the preset's rules,
+// including the loudness range and format requirements that Check alone
+// cannot see. The audio data is not modified.
+func (p *Preset) Verify(audioData *audio.AudioData) (*ComplianceResult, error) {
+	result, err := MeasureLoudness(audioData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to measure loudness: %w", err)
+	}
+	result.LoudnessRange = MeasureLoudnessRange(audioData)
+
+	compliance := p.Check(result)
+	compliance.RangeMeasured = true
+
+	if p.Rules.SampleRate > 0 && audioData.SampleRate != p.Rules.SampleRate {
+		compliance.Violations = append(compliance.Violations, fmt.Sprintf("sample rate %d Hz, expected %d Hz",
+			audioData.SampleRate, p.Rules.SampleRate))
+	}
+
+	if p.Rules.BitDepth > 0 && audioData.BitDepth != p.Rules.BitDepth {
+		compliance.Violations = append(compliance.Violations, fmt.Sprintf("bit depth %d bits, expected %d bits",
+			audioData.BitDepth, p.Rules.BitDepth))
+	}
+
+	return compliance, nil
+}
+
+// PrintComplianceSummary displays a summary of all compliance results
+func PrintComplianceSummary(results []*ComplianceResult) {
+	fmt.Printf("\nCompliance Check (%s):\n", results[0].Preset)
+
+	failed := 0
+	for i, result := range results {
+		measurement := result.Measurement
+		fmt.Printf("[%d] %s: %.1f LUFS, %.1f dBTP, RMS %.1f dBFS",
+			i+1, result.Filename, measurement.IntegratedLoudness, measurement.TruePeak, measurement.RMSLevel)
+		if result.RangeMeasured {
+			fmt.Printf(", LRA %.1f LU", measurement.LoudnessRange)
+		}
+
+		if result.Passed() {
+			fmt.Printf(" ✓ PASS\n")
+			continue
+		}
+
+		failed++
+		fmt.Printf(" ✗ FAIL\n")
+		for _, violation := range result.Violations {
+			fmt.Printf("    - %s\n", violation)
+		}
+	}
+
+	if failed > 0 {
+		fmt.Printf("\n⚠️  %d file(s) do not meet the %s requirements\n", failed, results[0].Preset)
+	} else {
+		fmt.Printf("\n✓ All files meet the %s requirements\n", results[0].Preset)
+	}
+}
@@ -0,0 +1,53 @@
+package loudness
+
+import (
+	"math"
+	"sort"
+
+	"void-cutter/internal/audio"
+	"void-cutter/internal/gating"
+)
+
+const (
+	shortTermBlockMs     = 3000  // Short-term loudness window (EBU Tech 3342)
+	rangeRelativeGate    = -20.0 // Relative gate for loudness range in LU
+	rangeLowPercentile   = 0.10
+	rangeHighPercentile  = 0.95
+	minRangeMeasurements = 2
+)
+
+// MeasureLoudnessRange calculates the loudness range (LRA) in LU following the
+// EBU Tech 3342 approach: the spread between the 10th and 95th percentiles of
+// gated short-term (3s) loudness. Returns 0 for audio too short to measure.
+func MeasureLoudnessRange(audioData *audio.AudioData) float64 {
+	if audioData == nil || len(audioData.Samples) == 0 {
+		return 0
+	}
+
+	blockPowers := gating.BlockMeanSquares(audioData.ToFloat(), audioData.Channels, audioData.SampleRate,
+		shortTermBlockMs, gating.HopMs)
+
+	absGated := gating.AbsoluteGate(blockPowers)
+	if len(absGated) < minRangeMeasurements {
+		return 0
+	}
+
+	relGated := gating.RelativeGate(absGated, rangeRelativeGate)
+	if len(relGated) < minRangeMeasurements {
+		return 0
+	}
+
+	loudness := make([]float64, len(relGated))
+	for i, power := range relGated {
+		loudness[i] = gating.PowerToLUFS(power)
+	}
+
+	sort.Float64s(loudness)
+	return percentile(loudness, rangeHighPercentile) - percentile(loudness, rangeLowPercentile)
+}
+
+// percentile returns the value at fraction p of sorted values
+func percentile(sorted []float64, p float64) float64 {
+	index := int(math.Round(p * float64(len(sorted)-1)))
+	return sorted[index]
+}
@@ -0,0 +1,51 @@
+package loudness
+
+import (
+	"math"
+	"testing"
+
+	"void-cutter/internal/audio"
+)
+
+// toneTrack joins 1 kHz sine segments given as seconds and LUFS into a mono track
+func toneTrack(segments [][2]float64) *audio.AudioData {
+	const sampleRate = 48000
+	var samples []float64
+	for _, segment := range segments {
+		amplitude := math.Sqrt(2 * math.Pow(10, (segment[1]+0.691)/10))
+		for i := 0; i < int(segment[0]*sampleRate); i++ {
+			samples = append(samples, amplitude*math.Sin(2*math.Pi*1000*float64(i)/sampleRate))
+		}
+	}
+
+	audioData := &audio.AudioData{SampleRate: sampleRate, Channels: 1, BitDepth: 24, Filename: "tone.wav"}
+	audioData.Samples = make([]int32, len(samples))
+	audioData.FromFloat(samples)
+	return audioData
+}
+
+func TestMeasureLoudnessRange(t *testing.T) {
+	tests := []struct {
+		name     string
+		segments [][2]float64
+		want     float64
+	}{
+		{"steady tone", [][2]float64{{20, -23}}, 0},
+		// 3s blocks with a 100ms hop: 71 blocks at each level and 29 in between,
+		// so the 10th and 95th percentiles fall on the two levels
+		{"two levels", [][2]float64{{10, -20}, {10, -30}}, 10},
+		// The -50 LUFS blocks fall below the -20 LU relative gate; the 10th
+		// percentile of the rest is the block with 11 of its 30 hops in the tone
+		{"relative gate", [][2]float64{{10, -20}, {10, -50}}, -10 * math.Log10(11.0/30)},
+		{"too short", [][2]float64{{2, -23}}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := MeasureLoudnessRange(toneTrack(tt.segments))
+			if math.Abs(got-tt.want) > 0.05 {
+				t.Errorf("got %.2f LU, want %.2f", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package loudness
+
+import (
+	"fmt"
+	"math"
+
+	"void-cutter/internal/audio"
+	"void-cutter/internal/gating"
+	"void-cutter/internal/silence"
+	"void-cutter/pkg/detector"
+)
+
+// MeasureSpeechLoudness measures loudness only over the chunks where the track
+// is active, so that a speaker who talks rarely is measured by their speech level
+// rather than by an average dominated by their silence.
+// Falls back to the ungated measurement if the track has no active chunks.
+func MeasureSpeechLoudness(audioData *audio.AudioData, activity *detector.TrackActivity) (*LoudnessResult, error) {
+	if activity == nil {
+		return nil, fmt.Errorf("activity is nil")
+	}
+
+	result, err := MeasureLoudness(audioData)
+	if err != nil {
+		return nil, err
+	}
+
+	fullScale := audioData.FullScale()
+	channels := audioData.Channels
+	frameCount := audioData.GetFrameCount()
+
+	var sumSquares float64
+	var sampleCount int
+	for chunk, active := range activity.Active {
+		if !active {
+			continue
+		}
+
+		startFrame := chunk * activity.ChunkFrames
+		endFrame := startFrame + activity.ChunkFrames
+		if endFrame > frameCount {
+			endFrame = frameCount
+		}
+
+		for i := startFrame * channels; i < endFrame*channels; i++ {
+			normalized := float64(audioData.Samples[i]) / fullScale
+			sumSquares += normalized * normalized
+		}
+		sampleCount += (endFrame - startFrame) * channels
+	}
+
+	if sampleCount == 0 || sumSquares == 0 {
+		fmt.Printf("  ⚠️  No active speech found in %s, using ungated loudness\n", audioData.Filename)
+		return result, nil
+	}
+
+	rmsDB := 10 * math.Log10(sumSquares/float64(sampleCount))
+	result.RMSLevel = rmsDB
+	result.IntegratedLoudness = rmsDB + gating.LUFSOffset
+	result.SpeechGated = true
+	result.SpeechRatio = activity.ActiveRatio()
+
+	return result, nil
+}
+
+// MeasureForNormalization measures a track the way the normalization config requires:
+// speech-gated when SpeechGated is set, otherwise over the whole track
+func MeasureForNormalization(audioData *audio.AudioData, config NormalizationConfig) (*LoudnessResult, error) {
+	if !config.SpeechGated {
+		return MeasureLoudness(audioData)
+	}
+
+	activity, err := silence.DetectActivity(audioData, config.Silence)
+	if err != nil {
+		return nil, fmt.Errorf("failed to detect speech activity: %w", err)
+	}
+
+	return MeasureSpeechLoudness(audioData, activity)
+}
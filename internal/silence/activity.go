@@ -0,0 +1,65 @@
+package silence
+
+import (
+	"fmt"
+
+	"void-cutter/internal/audio"
+	"void-cutter/pkg/detector"
+)
+
+// DetectActivity marks the active chunks of a track with the detector selected
+// by config.Mode (the RMS detector when empty)
+func DetectActivity(audioData *audio.AudioData, config SilenceDetectionConfig) (*detector.TrackActivity, error) {
+	if audioData == nil {
+		return nil, fmt.Errorf("audio data is nil")
+	}
+
+	if err := config.ValidateChannels(audioData.Channels); err != nil {
+		return nil, err
+	}
+
+	mode := config.Mode
+	if mode == "" {
+		mode = ModeRMS
+	}
+	d, err := detector.Lookup(mode)
+	if err != nil {
+		return nil, err
+	}
+	return d.Detect(audioData, config.Config)
+}
+
+// levelDetector marks chunks above the silence threshold as active, applying
+// the configured hysteresis, minimum burst, hold and release. The adaptive
+// variant derives the threshold from the track's noise floor.
+type levelDetector struct {
+	adaptive bool
+}
+
+// Detect implements detector.Detector
+func (d levelDetector) Detect(audioData *audio.AudioData, config detector.Config) (*detector.TrackActivity, error) {
+	return thresholdActivity(audioData, config, d.adaptive, func(levels []float64, threshold float64, chunkFrames int) []bool {
+		chunkMs := float64(chunkFrames) / float64(audioData.SampleRate) * 1000
+		return activityFromLevels(levels, threshold, config, chunkMs)
+	}), nil
+}
+
+// thresholdActivity measures the chunk levels and the track's threshold and
+// builds its activity from the decisions of decide
+func thresholdActivity(audioData *audio.AudioData, config detector.Config, adaptive bool,
+	decide func(levels []float64, threshold float64, chunkFrames int) []bool) *detector.TrackActivity {
+	chunkFrames, levels, channelLevels := detector.MeasureLevelsPerChannel(audioData, config)
+	chunkMs := float64(chunkFrames) / float64(audioData.SampleRate) * 1000
+	threshold, noiseFloor, reference := trackThreshold(config, adaptive, levels, chunkMs)
+
+	return &detector.TrackActivity{
+		ChunkFrames:     chunkFrames,
+		LevelsDB:        levels,
+		ChannelLevelsDB: channelLevels,
+		Active:          decide(levels, threshold, chunkFrames),
+		ThresholdDB:     threshold,
+		NoiseFloor:      noiseFloor,
+		ReferenceLUFS:   reference,
+		Filename:        audioData.Filename,
+	}
+}
@@ -0,0 +1,77 @@
+package silence
+
+import (
+	"math"
+	"sort"
+
+	"void-cutter/pkg/detector"
+)
+
+const (
+	histogramBinDB         = 1.0    // Level histogram resolution
+	histogramFloorDB       = -120.0 // Levels below this are treated as digital silence
+	maxAdaptiveThresholdDB = -20.0  // Adaptive thresholds never exceed this level
+)
+
+// EstimateNoiseFloor estimates a track's noise floor in dBFS from the histogram of
+// its chunk levels: the most common level among the quieter half of the chunks.
+// Returns -120 dBFS for tracks without any measurable level.
+func EstimateNoiseFloor(levelsDB []float64) float64 {
+	var levels []float64
+	for _, level := range levelsDB {
+		if !math.IsInf(level, 0) && level > histogramFloorDB {
+			levels = append(levels, level)
+		}
+	}
+	if len(levels) == 0 {
+		return histogramFloorDB
+	}
+
+	sort.Float64s(levels)
+	quieter := levels[:len(levels)/2+1]
+
+	// Most populated bin among the quieter half
+	counts := make(map[int]int)
+	bestBin, bestCount := 0, 0
+	for _, level := range quieter {
+		bin := int(math.Floor(level / histogramBinDB))
+		counts[bin]++
+		if counts[bin] > bestCount || (counts[bin] == bestCount && bin < bestBin) {
+			bestBin, bestCount = bin, counts[bin]
+		}
+	}
+
+	// Refine with the mean of the peak bin and its neighbours
+	var sum float64
+	count := 0
+	for _, level := range quieter {
+		if bin := int(math.Floor(level / histogramBinDB)); bin >= bestBin-1 && bin <= bestBin+1 {
+			sum += level
+			count++
+		}
+	}
+	return sum / float64(count)
+}
+
+// trackThreshold returns the silence threshold for a track, its estimated noise
+// floor and the reference loudness of a relative threshold. An adaptive
+// threshold takes precedence over a relative one; without either the
+// configured value is used. Values that do not apply are reported as NaN.
+func trackThreshold(config detector.Config, adaptive bool, levelsDB []float64, chunkMs float64) (float64, float64, float64) {
+	switch {
+	case adaptive:
+		noiseFloor := EstimateNoiseFloor(levelsDB)
+		return math.Min(noiseFloor+config.MarginDB, maxAdaptiveThresholdDB), noiseFloor, math.NaN()
+
+	case config.RelativeTo != "":
+		reference := ReferenceLoudness(levelsDB, chunkMs, config)
+		if math.IsInf(reference, -1) {
+			// Nothing loud enough to measure: fall back to the absolute threshold
+			return config.ThresholdDBFS, math.NaN(), reference
+		}
+		return reference - config.RelativeDB, math.NaN(), reference
+
+	default:
+		return config.ThresholdDBFS, math.NaN(), math.NaN()
+	}
+}
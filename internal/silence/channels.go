@@ -0,0 +1,81 @@
+package silence
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Channel selections for detection
+const (
+	ChannelsMix = "mix" // Level of all channels together
+	ChannelsAny = "any" // Active when any channel is active
+)
+
+// ParseChannelSelection parses a detection channel selection: "mix", "any", or a
+// comma-separated list of 1-based channel numbers such as "1,3". It returns
+// whether channels are analyzed separately and the selected 0-based channels
+// (empty for all channels).
+func ParseChannelSelection(spec string) (bool, []int, error) {
+	switch spec {
+	case "", ChannelsMix:
+		return false, nil, nil
+	case ChannelsAny:
+		return true, nil, nil
+	}
+
+	var channels []int
+	for _, part := range strings.Split(spec, ",") {
+		channel, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil || channel < 1 {
+			return false, nil, fmt.Errorf("invalid detection channel %q (expected mix, any or channel numbers like 1,2)", part)
+		}
+		if !slices.Contains(channels, channel-1) {
+			channels = append(channels, channel-1)
+		}
+	}
+	return true, channels, nil
+}
+
+// formatChannels formats 0-based channels as 1-based channel numbers
+func formatChannels(channels []int) string {
+	names := make([]string, len(channels))
+	for i, channel := range channels {
+		names[i] = strconv.Itoa(channel + 1)
+	}
+	return strings.Join(names, ",")
+}
+
+// PrintChannelLevels displays the per-channel levels measured for detection
+func (dr *DetectionResult) PrintChannelLevels() {
+	fmt.Printf("\nPer-Channel Detection Levels:\n")
+
+	for i, activity := range dr.Activities {
+		fmt.Printf("[%d] %s (threshold %.1f dBFS)\n", i+1, activity.Filename, activity.ThresholdDB)
+		if activity.ChannelLevelsDB == nil {
+			fmt.Printf("    mono\n")
+			continue
+		}
+
+		used := dr.Config.DetectionChannels(len(activity.ChannelLevelsDB))
+		for ch, levels := range activity.ChannelLevelsDB {
+			loudest := math.Inf(-1)
+			above := 0
+			for _, level := range levels {
+				loudest = math.Max(loudest, level)
+				if level > activity.ThresholdDB {
+					above++
+				}
+			}
+
+			fmt.Printf("    ch%d: noise floor %.1f dBFS, loudest chunk %.1f dBFS, %.1f%% above threshold",
+				ch+1, EstimateNoiseFloor(levels), loudest, float64(above)/float64(max(len(levels), 1))*100)
+			if !slices.Contains(used, ch) {
+				fmt.Printf(" (not used)")
+			}
+			fmt.Println()
+		}
+	}
+}
@@ -42,15 +42,15 @@ func CutSilenceRegions(audioData *audio.AudioData, silenceRegions []SilenceRegio
 			continue
 		}
 
-		// Calculate how much to cut
-		cutDuration := region.Duration - keepDurationSec
-		cutFrames := int(cutDuration * float64(audioData.SampleRate))
+		// Calculate how much to cut (in whole frames, so that cut points are exact)
+		keepFrames := int(keepDurationSec * float64(audioData.SampleRate))
+		cutFrames := region.EndFrame - region.StartFrame - keepFrames
 		cutSamples := cutFrames * audioData.Channels
 
 		// Calculate cut positions
 		startSample := region.StartFrame * audioData.Channels
 		endSample := region.EndFrame * audioData.Channels
-		keepSamples := int(keepDurationSec*float64(audioData.SampleRate)) * audioData.Channels
+		keepSamples := keepFrames * audioData.Channels
 
 		// Ensure we don't exceed array bounds
 		if endSample > len(modifiedSamples) {
@@ -5,6 +5,7 @@ import (
 	"math"
 
 	"void-cutter/internal/audio"
+	"void-cutter/pkg/detector"
 )
 
 // SilenceRegion represents a detected silence region
@@ -16,28 +17,51 @@ type SilenceRegion struct {
 	EndTime    float64 // End time in seconds
 }
 
-// SilenceDetectionConfig holds parameters for silence detection
+// SilenceDetectionConfig holds parameters for common silence detection: the
+// settings passed to each track's detector and how their activity is merged
 type SilenceDetectionConfig struct {
-	ThresholdDBFS float64 // Silence threshold in dBFS
-	MinDurationMs int     // Minimum silence duration in milliseconds
-	ChunkSizeMs   int     // Analysis chunk size in milliseconds (default: 10ms)
+	detector.Config // Per-track detection settings passed to the detector
+
+	Mode          string // Registered detector name (default: ModeRMS)
+	MinDurationMs int    // Minimum silence duration in milliseconds
+
+	// Move region boundaries from the chunk grid to the frame where the
+	// envelope crosses the threshold
+	RefineBoundaries bool
+
+	// Common silence decision: Quorum is the number of deciding tracks that must
+	// be silent (0 for all, N for at least N, -N for all but N). Excluded tracks,
+	// indexed like the audio files, do not take part but are still cut in sync.
+	Quorum   int
+	Excluded []bool
 }
 
 // DetectionResult contains the results of silence detection
 type DetectionResult struct {
-	CommonSilenceRegions []SilenceRegion   // Regions silent in ALL tracks
-	IndividualSilence    [][]SilenceRegion // Per-file silence regions
-	TotalCommonSilence   float64           // Total duration of common silence
+	CommonSilenceRegions []SilenceRegion           // Regions silent in enough deciding tracks (all by default)
+	IndividualSilence    [][]SilenceRegion         // Per-file silence regions
+	TotalCommonSilence   float64                   // Total duration of common silence
+	Thresholds           []float64                 // Per-file silence threshold in dBFS
+	NoiseFloors          []float64                 // Per-file estimated noise floor (NaN unless adaptive)
+	References           []float64                 // Per-file reference loudness of a relative threshold (NaN otherwise)
+	Activities           []*detector.TrackActivity // Per-file activity from the detector
 	AudioFiles           []*audio.AudioData
 	Config               SilenceDetectionConfig
 }
 
-// DetectCommonSilence finds silence regions that are common across all audio files
+// DetectCommonSilence finds silence regions that are common across all audio files.
+// Each file's activity comes from the detector selected by config.Mode; a chunk is
+// common silence when the quorum of tracks not excluded from the decision is silent.
 func DetectCommonSilence(audioFiles []*audio.AudioData, config SilenceDetectionConfig) (*DetectionResult, error) {
 	if len(audioFiles) == 0 {
 		return nil, fmt.Errorf("no audio files provided")
 	}
 
+	required, err := config.requiredSilentTracks(len(audioFiles))
+	if err != nil {
+		return nil, err
+	}
+
 	// Validate all files have same format
 	reference := audioFiles[0]
 	for _, audio := range audioFiles[1:] {
@@ -47,10 +71,7 @@ func DetectCommonSilence(audioFiles []*audio.AudioData, config SilenceDetectionC
 	}
 
 	// Calculate chunk size in frames
-	chunkFrames := (config.ChunkSizeMs * reference.SampleRate) / 1000
-	if chunkFrames == 0 {
-		chunkFrames = 1
-	}
+	chunkFrames := config.ChunkFrames(reference.SampleRate)
 
 	// Find the shortest audio duration to analyze
 	minFrames := reference.GetFrameCount()
@@ -63,50 +84,49 @@ func DetectCommonSilence(audioFiles []*audio.AudioData, config SilenceDetectionC
 	fmt.Printf("Analyzing %d frames in chunks of %d frames (%.1fms)\n",
 		minFrames, chunkFrames, float64(config.ChunkSizeMs))
 
-	// Detect silence in each chunk across all files
-	var commonSilenceRegions []SilenceRegion
-	var currentSilenceStart = -1
-
-	for frameStart := 0; frameStart < minFrames; frameStart += chunkFrames {
-		frameEnd := frameStart + chunkFrames
-		if frameEnd > minFrames {
-			frameEnd = minFrames
+	// Per-file activity with its own threshold (the same for every file unless adaptive or relative)
+	activities := make([]*detector.TrackActivity, len(audioFiles))
+	thresholds := make([]float64, len(audioFiles))
+	noiseFloors := make([]float64, len(audioFiles))
+	references := make([]float64, len(audioFiles))
+	for i, audioData := range audioFiles {
+		activity, err := DetectActivity(audioData, config)
+		if err != nil {
+			return nil, fmt.Errorf("failed to detect activity in %s: %w", audioData.Filename, err)
 		}
+		activities[i] = activity
+		thresholds[i], noiseFloors[i] = activity.ThresholdDB, activity.NoiseFloor
+		references[i] = activity.ReferenceLUFS
+	}
 
-		// Check if this chunk is silent in ALL files
-		isCommonSilence := true
-		for _, audioData := range audioFiles {
-			if !isChunkSilent(audioData, frameStart, frameEnd, config.ThresholdDBFS) {
-				isCommonSilence = false
-				break
-			}
-		}
+	// Detect silence in each chunk of every file; common silence is where enough deciding files are silent
+	minDuration := float64(config.MinDurationMs) / 1000.0
+	common := regionBuilder{start: -1}
+	individual := make([]regionBuilder, len(audioFiles))
+	for i := range individual {
+		individual[i].start = -1
+	}
 
-		// Track silence regions
-		if isCommonSilence {
-			if currentSilenceStart == -1 {
-				currentSilenceStart = frameStart
-			}
-		} else {
-			// End of silence region
-			if currentSilenceStart != -1 {
-				silenceRegion := createSilenceRegion(currentSilenceStart, frameStart, reference.SampleRate)
-
-				// Check if silence duration meets minimum requirement
-				if silenceRegion.Duration >= float64(config.MinDurationMs)/1000.0 {
-					commonSilenceRegions = append(commonSilenceRegions, silenceRegion)
-				}
-				currentSilenceStart = -1
+	for frameStart := 0; frameStart < minFrames; frameStart += chunkFrames {
+		silentTracks := 0
+		for i, activity := range activities {
+			silent := !activity.IsFrameActive(frameStart)
+			individual[i].update(silent, frameStart, minDuration, reference.SampleRate)
+			if silent && !config.isExcluded(i) {
+				silentTracks++
 			}
 		}
+		common.update(silentTracks >= required, frameStart, minDuration, reference.SampleRate)
 	}
 
-	// Handle silence region that extends to the end
-	if currentSilenceStart != -1 {
-		silenceRegion := createSilenceRegion(currentSilenceStart, minFrames, reference.SampleRate)
-		if silenceRegion.Duration >= float64(config.MinDurationMs)/1000.0 {
-			commonSilenceRegions = append(commonSilenceRegions, silenceRegion)
-		}
+	// Handle silence regions that extend to the end
+	commonSilenceRegions := common.finish(minFrames, minDuration, reference.SampleRate)
+	if config.RefineBoundaries {
+		commonSilenceRegions = refineRegions(audioFiles, activities, commonSilenceRegions, config, required, minFrames)
+	}
+	individualSilence := make([][]SilenceRegion, len(audioFiles))
+	for i := range individual {
+		individualSilence[i] = individual[i].finish(minFrames, minDuration, reference.SampleRate)
 	}
 
 	// Calculate total common silence duration
@@ -117,64 +137,91 @@ func DetectCommonSilence(audioFiles []*audio.AudioData, config SilenceDetectionC
 
 	return &DetectionResult{
 		CommonSilenceRegions: commonSilenceRegions,
-		IndividualSilence:    nil, // Not implemented in this version
+		IndividualSilence:    individualSilence,
 		TotalCommonSilence:   totalCommonSilence,
+		Thresholds:           thresholds,
+		NoiseFloors:          noiseFloors,
+		References:           references,
+		Activities:           activities,
 		AudioFiles:           audioFiles,
 		Config:               config,
 	}, nil
 }
 
-// isChunkSilent checks if a chunk of audio is below the silence threshold
-func isChunkSilent(audioData *audio.AudioData, startFrame, endFrame int, thresholdDBFS float64) bool {
-	if startFrame >= endFrame || endFrame > audioData.GetFrameCount() {
-		return true // Consider out-of-bounds as silent
-	}
+// isExcluded reports whether track i is excluded from the common silence decision
+func (c SilenceDetectionConfig) isExcluded(i int) bool {
+	return i < len(c.Excluded) && c.Excluded[i]
+}
 
-	channels := audioData.Channels
-	startSample := startFrame * channels
-	endSample := endFrame * channels
+// ValidateQuorum checks that the quorum can be met with the given number of tracks
+func (c SilenceDetectionConfig) ValidateQuorum(trackCount int) error {
+	_, err := c.requiredSilentTracks(trackCount)
+	return err
+}
 
-	if endSample > len(audioData.Samples) {
-		endSample = len(audioData.Samples)
+// requiredSilentTracks resolves the quorum to the number of deciding tracks
+// that must be silent for common silence
+func (c SilenceDetectionConfig) requiredSilentTracks(trackCount int) (int, error) {
+	deciding := 0
+	for i := 0; i < trackCount; i++ {
+		if !c.isExcluded(i) {
+			deciding++
+		}
 	}
-
-	// Calculate RMS for this chunk
-	var sumSquares float64
-	sampleCount := endSample - startSample
-
-	// Get appropriate normalization factor based on bit depth
-	var normalizationFactor float64
-	switch audioData.BitDepth {
-	case 16:
-		normalizationFactor = 32768.0 // 2^15
-	case 24:
-		normalizationFactor = 8388608.0 // 2^23
-	case 32:
-		normalizationFactor = 2147483648.0 // 2^31
-	default:
-		normalizationFactor = 32768.0 // Default to 16-bit
+	if deciding == 0 {
+		return 0, fmt.Errorf("all tracks are excluded from silence detection")
 	}
 
-	for i := startSample; i < endSample; i++ {
-		// Normalize to [-1, 1] range using appropriate factor
-		normalized := float64(audioData.Samples[i]) / normalizationFactor
-		sumSquares += normalized * normalized
+	required := deciding
+	switch {
+	case c.Quorum > 0:
+		required = c.Quorum
+	case c.Quorum < 0:
+		required = deciding + c.Quorum
 	}
-
-	if sampleCount == 0 {
-		return true
+	if required < 1 || required > deciding {
+		return 0, fmt.Errorf("silence quorum %d is not possible with %d deciding tracks", c.Quorum, deciding)
 	}
+	return required, nil
+}
 
-	rms := math.Sqrt(sumSquares / float64(sampleCount))
+// regionBuilder collects runs of silent chunks into silence regions
+type regionBuilder struct {
+	start   int // Start frame of the current run, -1 outside silence
+	regions []SilenceRegion
+}
 
-	// Convert to dBFS
-	if rms == 0 {
-		return true // Perfect silence
+// update records whether the chunk starting at frame is silent
+func (b *regionBuilder) update(silent bool, frame int, minDuration float64, sampleRate int) {
+	if silent {
+		if b.start == -1 {
+			b.start = frame
+		}
+		return
 	}
 
-	rmsDBFS := 20 * math.Log10(rms)
+	// End of silence region
+	if b.start != -1 {
+		b.add(frame, minDuration, sampleRate)
+		b.start = -1
+	}
+}
 
-	return rmsDBFS <= thresholdDBFS
+// finish closes a run that extends to endFrame and returns the regions
+func (b *regionBuilder) finish(endFrame int, minDuration float64, sampleRate int) []SilenceRegion {
+	if b.start != -1 {
+		b.add(endFrame, minDuration, sampleRate)
+		b.start = -1
+	}
+	return b.regions
+}
+
+// add keeps the current run if it meets the minimum duration
+func (b *regionBuilder) add(endFrame int, minDuration float64, sampleRate int) {
+	region := createSilenceRegion(b.start, endFrame, sampleRate)
+	if region.Duration >= minDuration {
+		b.regions = append(b.regions, region)
+	}
 }
 
 // createSilenceRegion creates a SilenceRegion from frame indices
@@ -195,31 +242,98 @@ func createSilenceRegion(startFrame, endFrame, sampleRate int) SilenceRegion {
 // Print displays the detection results
 func (dr *DetectionResult) Print() {
 	fmt.Printf("\nSilence Detection Results:\n")
-	fmt.Printf("Threshold: %.1f dBFS\n", dr.Config.ThresholdDBFS)
+	if dr.Config.Mode != "" && dr.Config.Mode != ModeRMS {
+		fmt.Printf("Detector: %s\n", dr.Config.Mode)
+	}
+	if dr.Config.Mode == ModeAdaptive {
+		fmt.Printf("Threshold: auto (noise floor + %.1f dB)\n", dr.Config.MarginDB)
+		for i, threshold := range dr.Thresholds {
+			fmt.Printf("  [%d] %s: noise floor %.1f dBFS → threshold %.1f dBFS\n",
+				i+1, dr.AudioFiles[i].Filename, dr.NoiseFloors[i], threshold)
+		}
+	} else if dr.Config.RelativeTo != "" {
+		fmt.Printf("Threshold: %.1f dB below %s loudness\n", dr.Config.RelativeDB, dr.Config.RelativeTo)
+		for i, threshold := range dr.Thresholds {
+			if math.IsInf(dr.References[i], -1) {
+				fmt.Printf("  [%d] %s: no measurable loudness → threshold %.1f dBFS\n",
+					i+1, dr.AudioFiles[i].Filename, threshold)
+				continue
+			}
+			fmt.Printf("  [%d] %s: %s loudness %.1f LUFS → threshold %.1f dBFS\n",
+				i+1, dr.AudioFiles[i].Filename, dr.Config.RelativeTo, dr.References[i], threshold)
+		}
+	} else {
+		fmt.Printf("Threshold: %.1f dBFS\n", dr.Config.ThresholdDBFS)
+	}
+	if dr.Config.PerChannel {
+		if len(dr.Config.Channels) == 0 {
+			fmt.Printf("Channels: any\n")
+		} else {
+			fmt.Printf("Channels: any of %s\n", formatChannels(dr.Config.Channels))
+		}
+	}
 	fmt.Printf("Min Duration: %d ms\n", dr.Config.MinDurationMs)
+	if dr.Config.RefineBoundaries {
+		fmt.Printf("Boundaries: sample-accurate\n")
+	}
 	fmt.Printf("Total Files: %d\n", len(dr.AudioFiles))
+	if required, err := dr.Config.requiredSilentTracks(len(dr.AudioFiles)); err == nil && required < len(dr.AudioFiles) {
+		deciding := len(dr.AudioFiles)
+		for i, audioData := range dr.AudioFiles {
+			if dr.Config.isExcluded(i) {
+				fmt.Printf("Excluded: [%d] %s (cut in sync)\n", i+1, audioData.Filename)
+				deciding--
+			}
+		}
+		fmt.Printf("Quorum: %d of %d tracks silent\n", required, deciding)
+	}
 	fmt.Printf("\nCommon Silence Regions: %d\n", len(dr.CommonSilenceRegions))
 
 	if len(dr.CommonSilenceRegions) == 0 {
 		fmt.Printf("No common silence regions found with current settings.\n")
-		return
+	} else {
+		// Refined boundaries are shown to the millisecond
+		precision := 2
+		if dr.Config.RefineBoundaries {
+			precision = 3
+		}
+		for i, region := range dr.CommonSilenceRegions {
+			fmt.Printf("[%d] %.*fs - %.*fs (%.*fs duration)\n",
+				i+1, precision, region.StartTime, precision, region.EndTime, precision, region.Duration)
+		}
+
+		fmt.Printf("\nTotal common silence: %.2fs (%.1f%% of audio)\n",
+			dr.TotalCommonSilence,
+			(dr.TotalCommonSilence/dr.AudioFiles[0].Duration)*100)
 	}
 
-	for i, region := range dr.CommonSilenceRegions {
-		fmt.Printf("[%d] %.2fs - %.2fs (%.2fs duration)\n",
-			i+1, region.StartTime, region.EndTime, region.Duration)
+	if len(dr.IndividualSilence) == 0 {
+		return
 	}
 
-	fmt.Printf("\nTotal common silence: %.2fs (%.1f%% of audio)\n",
-		dr.TotalCommonSilence,
-		(dr.TotalCommonSilence/dr.AudioFiles[0].Duration)*100)
+	fmt.Printf("\nIndividual Silence:\n")
+	for i, regions := range dr.IndividualSilence {
+		total := 0.0
+		for _, region := range regions {
+			total += region.Duration
+		}
+
+		audioData := dr.AudioFiles[i]
+		fmt.Printf("[%d] %s: %d regions, %.2fs (%.1f%% of audio)\n",
+			i+1, audioData.Filename, len(regions), total, total/audioData.Duration*100)
+		for _, region := range regions {
+			fmt.Printf("    %.2fs - %.2fs (%.2fs duration)\n", region.StartTime, region.EndTime, region.Duration)
+		}
+	}
 }
 
 // DefaultSilenceConfig returns default silence detection configuration
 func DefaultSilenceConfig() SilenceDetectionConfig {
 	return SilenceDetectionConfig{
-		ThresholdDBFS: -50.0,
+		Config: detector.Config{
+			ThresholdDBFS: -50.0,
+			ChunkSizeMs:   10,
+		},
 		MinDurationMs: 500,
-		ChunkSizeMs:   10,
 	}
 }
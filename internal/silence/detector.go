@@ -0,0 +1,16 @@
+package silence
+
+import "void-cutter/pkg/detector"
+
+// Built-in detectors
+const (
+	ModeRMS      = "rms"      // Chunk RMS level against the threshold
+	ModeAdaptive = "adaptive" // Chunk RMS level against a threshold above the track's noise floor
+	ModeVAD      = "vad"      // Voice activity detection on spectral features
+)
+
+func init() {
+	detector.Register(ModeRMS, levelDetector{})
+	detector.Register(ModeAdaptive, levelDetector{adaptive: true})
+	detector.Register(ModeVAD, vadDetector{})
+}
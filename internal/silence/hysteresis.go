@@ -0,0 +1,78 @@
+package silence
+
+import (
+	"math"
+
+	"void-cutter/pkg/detector"
+)
+
+// activityFromLevels decides per chunk whether a track is active. Non-silent
+// bursts shorter than MinBurstMs are ignored. When the level drops to the
+// threshold, the last level above it is held for HoldMs and then falls
+// linearly in dB so that it reaches the threshold after another ReleaseMs.
+// The state changes with hysteresis: silence is entered at or below the
+// threshold and only broken above the threshold plus HysteresisDB.
+// With all of these settings at zero a chunk is active when its level exceeds the threshold.
+func activityFromLevels(levels []float64, threshold float64, config detector.Config, chunkMs float64) []bool {
+	exitThreshold := threshold + config.HysteresisDB
+
+	// Ignore short bursts (breaths, clicks) so that they do not break silence
+	filtered := levels
+	if minBurst := int(math.Ceil(float64(config.MinBurstMs) / chunkMs)); minBurst > 1 {
+		filtered = make([]float64, len(levels))
+		copy(filtered, levels)
+
+		runStart := -1
+		for chunk := 0; chunk <= len(levels); chunk++ {
+			loud := chunk < len(levels) && levels[chunk] > threshold
+			if loud && runStart < 0 {
+				runStart = chunk
+			}
+			if !loud && runStart >= 0 {
+				if chunk-runStart < minBurst {
+					for i := runStart; i < chunk; i++ {
+						filtered[i] = math.Inf(-1)
+					}
+				}
+				runStart = -1
+			}
+		}
+	}
+
+	holdChunks := int(math.Ceil(float64(config.HoldMs) / chunkMs))
+	releaseChunks := int(math.Ceil(float64(config.ReleaseMs) / chunkMs))
+
+	active := make([]bool, len(levels))
+	envelope := math.Inf(-1)
+	lastLoud := math.Inf(-1) // Last level above the threshold
+	below := 0               // Chunks since the level dropped to the threshold
+	state := false
+	for chunk, level := range filtered {
+		if level > threshold {
+			envelope, lastLoud = level, level
+			below = 0
+		} else {
+			below++
+			switch {
+			case lastLoud <= threshold:
+				envelope = level
+			case below <= holdChunks:
+				envelope = lastLoud
+			case below < holdChunks+releaseChunks:
+				progress := float64(below-holdChunks) / float64(releaseChunks)
+				envelope = lastLoud - (lastLoud-threshold)*progress
+			default:
+				envelope = level
+			}
+		}
+
+		if envelope > exitThreshold {
+			state = true
+		} else if envelope <= threshold {
+			state = false
+		}
+		active[chunk] = state
+	}
+
+	return active
+}
@@ -0,0 +1,77 @@
+package silence
+
+import (
+	"slices"
+	"testing"
+
+	"void-cutter/pkg/detector"
+)
+
+func TestActivityFromLevels(t *testing.T) {
+	const threshold = -50.0
+	const chunkMs = 10.0
+
+	tests := []struct {
+		name   string
+		config detector.Config
+		levels []float64
+		want   []bool
+	}{
+		{
+			name:   "plain threshold",
+			levels: []float64{-60, -40, -50, -49, -70},
+			want:   []bool{false, true, false, true, false},
+		},
+		{
+			name:   "hold starts when the level drops",
+			config: detector.Config{HoldMs: 30},
+			levels: []float64{-20, -25, -22, -30, -60, -60, -60, -60, -60},
+			want:   []bool{true, true, true, true, true, true, true, false, false},
+		},
+		{
+			name:   "hold restarts after each loud chunk",
+			config: detector.Config{HoldMs: 20},
+			levels: []float64{-20, -60, -30, -60, -60, -60},
+			want:   []bool{true, true, true, true, true, false},
+		},
+		{
+			name:   "release reaches the threshold after ReleaseMs",
+			config: detector.Config{ReleaseMs: 50},
+			levels: []float64{-10, -60, -60, -60, -60, -60, -60},
+			want:   []bool{true, true, true, true, true, false, false},
+		},
+		{
+			name:   "release does not depend on the speech level",
+			config: detector.Config{ReleaseMs: 50},
+			levels: []float64{-45, -60, -60, -60, -60, -60, -60},
+			want:   []bool{true, true, true, true, true, false, false},
+		},
+		{
+			name:   "hold then release",
+			config: detector.Config{HoldMs: 20, ReleaseMs: 20},
+			levels: []float64{-20, -60, -60, -60, -60, -60},
+			want:   []bool{true, true, true, true, false, false},
+		},
+		{
+			name:   "hysteresis",
+			config: detector.Config{HysteresisDB: 6},
+			levels: []float64{-60, -47, -40, -47, -55, -47},
+			want:   []bool{false, false, true, true, false, false},
+		},
+		{
+			name:   "short bursts are ignored",
+			config: detector.Config{MinBurstMs: 30},
+			levels: []float64{-60, -20, -20, -60, -20, -20, -20, -60},
+			want:   []bool{false, false, false, false, true, true, true, false},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := activityFromLevels(tt.levels, threshold, tt.config, chunkMs)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("activityFromLevels(%v) = %v, want %v", tt.levels, got, tt.want)
+			}
+		})
+	}
+}
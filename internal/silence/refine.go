@@ -0,0 +1,200 @@
+package silence
+
+import (
+	"math"
+
+	"void-cutter/internal/audio"
+	"void-cutter/pkg/detector"
+)
+
+const envelopeWindowMs = 1.0 // Short-term envelope used to place boundaries between chunks
+
+// refineRegions moves the boundaries of common silence regions from the chunk
+// grid to the frame where the short-term envelope crosses the threshold. Only
+// the chunks on either side of a boundary are searched; a boundary without a
+// crossing there (e.g. while activity is held) stays where it is.
+func refineRegions(audioFiles []*audio.AudioData, activities []*detector.TrackActivity, regions []SilenceRegion,
+	config SilenceDetectionConfig, required, endFrame int) []SilenceRegion {
+	if len(regions) == 0 {
+		return regions
+	}
+
+	// Envelopes come from the same signal the levels were measured on
+	signals := make([]*audio.AudioData, len(audioFiles))
+	for i, audioData := range audioFiles {
+		signals[i] = audioData
+		if !config.isExcluded(i) {
+			signals[i] = detector.DetectionSignal(audioData, config.Config)
+		}
+	}
+
+	sampleRate := audioFiles[0].SampleRate
+	window := max(1, int(envelopeWindowMs*float64(sampleRate)/1000))
+	chunkFrames := activities[0].ChunkFrames
+
+	silentAt := func(frame int) bool {
+		silentTracks := 0
+		for i, signal := range signals {
+			if config.isExcluded(i) {
+				continue
+			}
+			if envelopeDB(signal, frame, window, config.Config) <= activities[i].ThresholdDB {
+				silentTracks++
+			}
+		}
+		return silentTracks >= required
+	}
+
+	refined := make([]SilenceRegion, len(regions))
+	for r, region := range regions {
+		start, end := region.StartFrame, region.EndFrame
+
+		// Silence starts after the last loud frame around the start boundary
+		if start > 0 {
+			for frame := min(start+chunkFrames, end) - 1; frame >= max(start-chunkFrames, 0); frame-- {
+				if !silentAt(frame) {
+					start = frame + 1
+					break
+				}
+			}
+		}
+
+		// and ends at the first loud frame around the end boundary
+		if end < endFrame {
+			for frame := max(end-chunkFrames, start); frame < min(end+chunkFrames, endFrame); frame++ {
+				if !silentAt(frame) {
+					end = frame
+					break
+				}
+			}
+		}
+
+		if start >= end {
+			start, end = region.StartFrame, region.EndFrame
+		}
+		refined[r] = createSilenceRegion(start, end, sampleRate)
+	}
+
+	return refined
+}
+
+// envelopeDB returns the RMS level in dBFS of a short window centered on a frame,
+// over the channels taking part in detection (the loudest one with PerChannel)
+func envelopeDB(audioData *audio.AudioData, frame, window int, config detector.Config) float64 {
+	channels := audioData.Channels
+	frameCount := audioData.GetFrameCount()
+	fullScale := audioData.FullScale()
+	startFrame := max(frame-window/2, 0)
+	endFrame := min(startFrame+window, frameCount)
+	if startFrame >= endFrame {
+		return math.Inf(-1)
+	}
+
+	power := 0.0
+	used := config.DetectionChannels(channels)
+	for _, ch := range used {
+		var sumSquares float64
+		for f := startFrame; f < endFrame; f++ {
+			normalized := float64(audioData.Samples[f*channels+ch]) / fullScale
+			sumSquares += normalized * normalized
+		}
+
+		if config.PerChannel {
+			power = math.Max(power, sumSquares)
+		} else {
+			power += sumSquares / float64(len(used))
+		}
+	}
+
+	if power == 0 {
+		return math.Inf(-1)
+	}
+	return 10 * math.Log10(power/float64(endFrame-startFrame))
+}
+
+// SnapCutPoints moves the points where CutSilenceRegions will cut each region
+// (keepDurationMs after its start, and its end) by up to half an analysis
+// chunk: to the nearest frame where every channel of every track crosses zero,
+// or to the frame with the lowest energy across tracks when there is none.
+// The returned regions are shifted accordingly; cuts never move into activity.
+func SnapCutPoints(audioFiles []*audio.AudioData, regions []SilenceRegion, keepDurationMs int, config SilenceDetectionConfig) []SilenceRegion {
+	if len(audioFiles) == 0 {
+		return regions
+	}
+
+	sampleRate := audioFiles[0].SampleRate
+	keepFrames := int(float64(keepDurationMs) / 1000.0 * float64(sampleRate))
+	window := max(1, config.ChunkFrames(sampleRate)/2)
+
+	frameCount := audioFiles[0].GetFrameCount()
+	for _, audioData := range audioFiles[1:] {
+		frameCount = min(frameCount, audioData.GetFrameCount())
+	}
+
+	snapped := make([]SilenceRegion, len(regions))
+	for r, region := range regions {
+		snapped[r] = region
+
+		cutStart := region.StartFrame + keepFrames
+		if cutStart >= region.EndFrame {
+			continue // Too short to be cut
+		}
+
+		// A cut up to the end of the tracks has no second edge to join
+		cutEnd := region.EndFrame
+		if cutEnd < frameCount {
+			cutEnd = snapFrame(audioFiles, cutEnd, max(cutEnd-window, cutStart+1), cutEnd)
+		}
+		// The shifted region must not start before the track
+		cutStart = snapFrame(audioFiles, cutStart, max(cutStart-window, region.StartFrame, keepFrames, 1), min(cutStart+window, cutEnd-1))
+		snapped[r] = createSilenceRegion(cutStart-keepFrames, cutEnd, sampleRate)
+	}
+
+	return snapped
+}
+
+// snapFrame returns the frame in [low, high] closest to target where all tracks
+// cross zero, or else the one with the lowest energy around it
+func snapFrame(audioFiles []*audio.AudioData, target, low, high int) int {
+	if low > high {
+		return target
+	}
+
+	best, bestDistance := -1, math.MaxInt
+	quietest, quietestEnergy := target, math.Inf(1)
+	for frame := low; frame <= high; frame++ {
+		crossing := true
+		energy := 0.0
+		for _, audioData := range audioFiles {
+			channels := audioData.Channels
+			fullScale := audioData.FullScale()
+			for ch := 0; ch < channels; ch++ {
+				before := float64(audioData.Samples[(frame-1)*channels+ch]) / fullScale
+				at := float64(audioData.Samples[frame*channels+ch]) / fullScale
+				crossing = crossing && (at == 0 || (before < 0) != (at < 0))
+				energy += before*before + at*at
+			}
+		}
+
+		distance := abs(frame - target)
+		if crossing && distance < bestDistance {
+			best, bestDistance = frame, distance
+		}
+		if energy < quietestEnergy || (energy == quietestEnergy && distance < abs(quietest-target)) {
+			quietest, quietestEnergy = frame, energy
+		}
+	}
+
+	if best >= 0 {
+		return best
+	}
+	return quietest
+}
+
+// abs returns the absolute value of an int
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
@@ -0,0 +1,63 @@
+package silence
+
+import (
+	"math"
+
+	"void-cutter/internal/gating"
+	"void-cutter/pkg/detector"
+)
+
+// Loudness references for relative thresholds
+const (
+	ReferenceIntegrated = "integrated" // Gated loudness of the whole track
+	ReferenceSpeech     = "speech"     // Loudness of the chunks within 10 LU of the integrated loudness
+)
+
+// ReferenceLoudness estimates a track's loudness in LUFS from its detection
+// chunk levels, using the simplified RMS-based model of the loudness package.
+// The integrated reference gates 400ms blocks like BS.1770; the speech
+// reference averages the chunks that pass a relative gate below the integrated
+// loudness, so that both follow any gain applied to the track. Returns -Inf if
+// nothing passes the gates.
+func ReferenceLoudness(levelsDB []float64, chunkMs float64, config detector.Config) float64 {
+	integrated := integratedLoudness(levelsDB, chunkMs)
+	if config.RelativeTo != ReferenceSpeech || math.IsInf(integrated, -1) {
+		return integrated
+	}
+
+	// Speech chunks are gated against the track's own loudness, not a dBFS level
+	gate := integrated + gating.RelativeGateOffset
+	var speech []float64
+	for _, level := range levelsDB {
+		if power := dbToPower(level); gating.PowerToLUFS(power) > gate {
+			speech = append(speech, power)
+		}
+	}
+	return gating.PowerToLUFS(gating.Mean(speech))
+}
+
+// integratedLoudness gates blocks of chunk powers like BS.1770
+func integratedLoudness(levelsDB []float64, chunkMs float64) float64 {
+	chunksPerBlock := max(1, int(math.Round(gating.BlockMs/chunkMs)))
+	hopChunks := max(1, int(math.Round(gating.HopMs/chunkMs)))
+	var blocks []float64
+	for start := 0; start == 0 || start+chunksPerBlock <= len(levelsDB); start += hopChunks {
+		end := min(start+chunksPerBlock, len(levelsDB))
+		if start >= end {
+			break
+		}
+
+		chunkPowers := make([]float64, 0, end-start)
+		for _, level := range levelsDB[start:end] {
+			chunkPowers = append(chunkPowers, dbToPower(level))
+		}
+		blocks = append(blocks, gating.Mean(chunkPowers))
+	}
+
+	return gating.Loudness(blocks)
+}
+
+// dbToPower converts a level in dB to a mean square
+func dbToPower(levelDB float64) float64 {
+	return math.Pow(10, levelDB/10)
+}
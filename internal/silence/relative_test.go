@@ -0,0 +1,69 @@
+package silence
+
+import (
+	"math"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"void-cutter/internal/audio"
+)
+
+// speechAndNoise alternates 1s of tone at speechDB with 1s of noise at noiseDB (RMS, dBFS)
+func speechAndNoise(speechDB, noiseDB float64) []float64 {
+	const sampleRate = 48000
+	rng := rand.New(rand.NewSource(1))
+	samples := make([]float64, 6*sampleRate)
+	for i := range samples {
+		if (i/sampleRate)%2 == 0 {
+			samples[i] = math.Sqrt2 * math.Pow(10, speechDB/20) * math.Sin(2*math.Pi*220*float64(i)/sampleRate)
+		} else {
+			samples[i] = math.Sqrt(3) * math.Pow(10, noiseDB/20) * (2*rng.Float64() - 1)
+		}
+	}
+	return samples
+}
+
+func TestRelativeThresholdFollowsGain(t *testing.T) {
+	signal := speechAndNoise(-40, -70)
+
+	for _, reference := range []string{ReferenceIntegrated, ReferenceSpeech} {
+		t.Run(reference, func(t *testing.T) {
+			config := DefaultSilenceConfig()
+			config.RelativeTo = reference
+			config.RelativeDB = 25
+
+			var baseRegions []SilenceRegion
+			var baseThreshold float64
+			for _, gainDB := range []float64{0, 10, 20, 30} {
+				gain := math.Pow(10, gainDB/20)
+				shifted := make([]float64, len(signal))
+				for i, sample := range signal {
+					shifted[i] = sample * gain
+				}
+				audioData := &audio.AudioData{SampleRate: 48000, Channels: 1, BitDepth: 24, Filename: "track.wav"}
+				audioData.Samples = make([]int32, len(shifted))
+				audioData.FromFloat(shifted)
+
+				result, err := DetectCommonSilence([]*audio.AudioData{audioData}, config)
+				if err != nil {
+					t.Fatalf("DetectCommonSilence: %v", err)
+				}
+
+				if gainDB == 0 {
+					baseRegions, baseThreshold = result.CommonSilenceRegions, result.Thresholds[0]
+					if len(baseRegions) == 0 {
+						t.Fatalf("no silence detected")
+					}
+					continue
+				}
+				if !slices.Equal(result.CommonSilenceRegions, baseRegions) {
+					t.Errorf("+%.0f dB: regions %v, want %v", gainDB, result.CommonSilenceRegions, baseRegions)
+				}
+				if shift := result.Thresholds[0] - baseThreshold; math.Abs(shift-gainDB) > 0.1 {
+					t.Errorf("+%.0f dB: threshold moved by %.2f dB", gainDB, shift)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,162 @@
+package silence
+
+import (
+	"math"
+
+	"void-cutter/internal/audio"
+	"void-cutter/internal/spectral"
+	"void-cutter/pkg/detector"
+)
+
+// vadFeatures are the per-chunk features used to classify speech
+type vadFeatures struct {
+	speechRatio float64
+	flatness    float64
+	zcr         float64
+}
+
+// isSpeech votes on the features: at least two of the three must look like speech
+func (f vadFeatures) isSpeech(config detector.VADConfig) bool {
+	votes := 0
+	if f.speechRatio >= config.MinSpeechRatio {
+		votes++
+	}
+	if f.flatness <= config.MaxFlatness {
+		votes++
+	}
+	if f.zcr >= config.MinZCR && f.zcr <= config.MaxZCR {
+		votes++
+	}
+	return votes >= 2
+}
+
+// vadDetector marks chunks as active when they are above the silence threshold
+// and look like speech
+type vadDetector struct{}
+
+// Detect implements detector.Detector
+func (vadDetector) Detect(audioData *audio.AudioData, config detector.Config) (*detector.TrackActivity, error) {
+	return thresholdActivity(audioData, config, false, func(levels []float64, threshold float64, chunkFrames int) []bool {
+		return vadActivity(audioData, levels, threshold, config, chunkFrames)
+	}), nil
+}
+
+// vadActivity classifies each chunk of a track as speech or not. Chunks must be
+// above the energy threshold and look like speech; the decisions are smoothed by
+// a state machine that requires OnsetMs of speech to start and holds for HangoverMs.
+func vadActivity(audioData *audio.AudioData, levels []float64, threshold float64, config detector.Config, chunkFrames int) []bool {
+	vad := config.VAD
+	if vad == (detector.VADConfig{}) {
+		vad = detector.DefaultVADConfig()
+	}
+
+	channels := audioData.Channels
+	frameCount := audioData.GetFrameCount()
+	samples := audioData.ToFloat()
+
+	// Features come from the mix of the channels taking part in detection
+	used := config.DetectionChannels(channels)
+	mono := make([]float64, frameCount)
+	for i := range mono {
+		for _, ch := range used {
+			mono[i] += samples[i*channels+ch]
+		}
+		mono[i] /= float64(len(used))
+	}
+
+	fftSize := spectral.NextPowerOfTwo(chunkFrames)
+	window := spectral.HannWindow(fftSize)
+	buffer := make([]complex128, fftSize)
+	lowBin := spectral.FrequencyBin(vad.BandLowHz, fftSize, audioData.SampleRate)
+	highBin := spectral.FrequencyBin(vad.BandHighHz, fftSize, audioData.SampleRate)
+	dcBin := max(1, spectral.FrequencyBin(80, fftSize, audioData.SampleRate)) // Ignore DC and rumble
+
+	chunkMs := float64(chunkFrames) / float64(audioData.SampleRate) * 1000
+	onsetChunks := max(1, int(math.Ceil(float64(vad.OnsetMs)/chunkMs)))
+	hangoverChunks := int(math.Ceil(float64(vad.HangoverMs) / chunkMs))
+
+	active := make([]bool, len(levels))
+	state := false
+	speechRun, hangover := 0, 0
+
+	for chunk, level := range levels {
+		speech := false
+		if level > threshold {
+			// Analysis frame centered on the chunk
+			start := chunk*chunkFrames + chunkFrames/2 - fftSize/2
+			for i := range buffer {
+				value := 0.0
+				if index := start + i; index >= 0 && index < frameCount {
+					value = mono[index] * window[i]
+				}
+				buffer[i] = complex(value, 0)
+			}
+			spectral.FFT(buffer)
+			power := spectral.PowerSpectrum(buffer)
+
+			end := min((chunk+1)*chunkFrames, frameCount)
+			features := chunkFeatures(power, mono[chunk*chunkFrames:end], dcBin, lowBin, highBin)
+			speech = features.isSpeech(vad)
+		}
+
+		if speech {
+			speechRun++
+		} else {
+			speechRun = 0
+		}
+
+		switch {
+		case speechRun >= onsetChunks:
+			if !state {
+				// Activity starts with the onset run
+				for i := chunk - speechRun + 1; i < chunk; i++ {
+					active[i] = true
+				}
+			}
+			state = true
+			hangover = hangoverChunks
+		case state && hangover > 0:
+			hangover--
+		default:
+			state = false
+		}
+		active[chunk] = state
+	}
+
+	return active
+}
+
+// chunkFeatures computes the speech-band energy ratio, spectral flatness and
+// zero-crossing rate of a chunk
+func chunkFeatures(power, signal []float64, dcBin, lowBin, highBin int) vadFeatures {
+	var total, band, logSum float64
+	bins := 0
+	for bin := dcBin; bin < len(power); bin++ {
+		total += power[bin]
+		if bin >= lowBin && bin <= highBin {
+			band += power[bin]
+			logSum += math.Log(power[bin] + 1e-20)
+			bins++
+		}
+	}
+
+	var features vadFeatures
+	if total > 0 {
+		features.speechRatio = band / total
+	}
+	if bins > 0 && band > 0 {
+		features.flatness = math.Exp(logSum/float64(bins)) / (band / float64(bins))
+	}
+
+	crossings := 0
+	for i := 1; i < len(signal); i++ {
+		if (signal[i-1] >= 0) != (signal[i] >= 0) {
+			crossings++
+		}
+	}
+	if len(signal) > 1 {
+		features.zcr = float64(crossings) / float64(len(signal)-1)
+	}
+
+	return features
+}
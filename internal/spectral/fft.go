@@ -0,0 +1,89 @@
+package spectral
+
+import (
+	"math"
+	"math/cmplx"
+)
+
+// FFT computes the discrete Fourier transform of x in place.
+// The length of x must be a power of two.
+func FFT(x []complex128) {
+	transform(x, false)
+}
+
+// IFFT computes the inverse discrete Fourier transform of x in place (scaled by 1/N).
+// The length of x must be a power of two.
+func IFFT(x []complex128) {
+	transform(x, true)
+	scale := complex(1/float64(len(x)), 0)
+	for i := range x {
+		x[i] *= scale
+	}
+}
+
+// transform is an iterative radix-2 Cooley-Tukey FFT
+func transform(x []complex128, inverse bool) {
+	n := len(x)
+	if n <= 1 {
+		return
+	}
+
+	// Bit-reversal permutation
+	for i, j := 1, 0; i < n; i++ {
+		bit := n >> 1
+		for ; j&bit != 0; bit >>= 1 {
+			j ^= bit
+		}
+		j ^= bit
+		if i < j {
+			x[i], x[j] = x[j], x[i]
+		}
+	}
+
+	sign := -1.0
+	if inverse {
+		sign = 1.0
+	}
+
+	for size := 2; size <= n; size <<= 1 {
+		step := cmplx.Exp(complex(0, sign*2*math.Pi/float64(size)))
+		for start := 0; start < n; start += size {
+			w := complex(1, 0)
+			for k := 0; k < size/2; k++ {
+				even := x[start+k]
+				odd := x[start+k+size/2] * w
+				x[start+k] = even + odd
+				x[start+k+size/2] = even - odd
+				w *= step
+			}
+		}
+	}
+}
+
+// NextPowerOfTwo returns the smallest power of two that is at least n
+func NextPowerOfTwo(n int) int {
+	size := 1
+	for size < n {
+		size <<= 1
+	}
+	return size
+}
+
+// HannWindow returns a periodic Hann window of length n
+func HannWindow(n int) []float64 {
+	window := make([]float64, n)
+	for i := range window {
+		window[i] = 0.5 - 0.5*math.Cos(2*math.Pi*float64(i)/float64(n))
+	}
+	return window
+}
+
+// BinFrequency returns the center frequency in Hz of an FFT bin
+func BinFrequency(bin, fftSize, sampleRate int) float64 {
+	return float64(bin) * float64(sampleRate) / float64(fftSize)
+}
+
+// FrequencyBin returns the FFT bin closest to a frequency in Hz
+func FrequencyBin(frequency float64, fftSize, sampleRate int) int {
+	return int(math.Round(frequency * float64(fftSize) / float64(sampleRate)))
+}
@@ -0,0 +1,105 @@
+package spectral
+
+import "math"
+
+// STFT performs short-time Fourier analysis and overlap-add resynthesis with
+// 50% overlapping square-root Hann windows, which reconstruct the input
+// exactly when the spectra are left unchanged
+type STFT struct {
+	FrameSize int
+	HopSize   int
+	window    []float64
+}
+
+// NewSTFT creates an STFT with the given frame size (rounded up to a power of two)
+func NewSTFT(frameSize int) *STFT {
+	frameSize = NextPowerOfTwo(frameSize)
+
+	window := HannWindow(frameSize)
+	for i := range window {
+		window[i] = math.Sqrt(window[i])
+	}
+
+	return &STFT{
+		FrameSize: frameSize,
+		HopSize:   frameSize / 2,
+		window:    window,
+	}
+}
+
+// FrameCount returns the number of frames used to cover a signal of length n
+func (s *STFT) FrameCount(n int) int {
+	if n == 0 {
+		return 0
+	}
+	// Frames start one hop before the signal so every sample is covered twice
+	return (n+s.HopSize-1)/s.HopSize + 1
+}
+
+// FrameStart returns the signal index where a frame starts (may be negative)
+func (s *STFT) FrameStart(frame int) int {
+	return frame*s.HopSize - s.HopSize
+}
+
+// Analyze returns the windowed spectrum of a frame of the signal
+func (s *STFT) Analyze(signal []float64, frame int) []complex128 {
+	spectrum := make([]complex128, s.FrameSize)
+	start := s.FrameStart(frame)
+	for i := range spectrum {
+		index := start + i
+		if index >= 0 && index < len(signal) {
+			spectrum[i] = complex(signal[index]*s.window[i], 0)
+		}
+	}
+	FFT(spectrum)
+	return spectrum
+}
+
+// Process analyses the signal frame by frame, lets fn modify each spectrum and
+// returns the overlap-added resynthesis. fn receives the full (two-sided) spectrum;
+// modifications should keep it conjugate-symmetric.
+func (s *STFT) Process(signal []float64, fn func(frame int, spectrum []complex128)) []float64 {
+	output := make([]float64, len(signal))
+
+	for frame := 0; frame < s.FrameCount(len(signal)); frame++ {
+		spectrum := s.Analyze(signal, frame)
+		fn(frame, spectrum)
+		IFFT(spectrum)
+
+		start := s.FrameStart(frame)
+		for i, value := range spectrum {
+			index := start + i
+			if index >= 0 && index < len(output) {
+				output[index] += real(value) * s.window[i]
+			}
+		}
+	}
+
+	return output
+}
+
+// PowerSpectrum returns |X|^2 for the non-negative frequency bins of a spectrum
+func PowerSpectrum(spectrum []complex128) []float64 {
+	power := make([]float64, len(spectrum)/2+1)
+	for i := range power {
+		re, im := real(spectrum[i]), imag(spectrum[i])
+		power[i] = re*re + im*im
+	}
+	return power
+}
+
+// Deinterleave extracts one channel from interleaved samples
+func Deinterleave(samples []float64, channels, channel int) []float64 {
+	out := make([]float64, len(samples)/channels)
+	for i := range out {
+		out[i] = samples[i*channels+channel]
+	}
+	return out
+}
+
+// Interleave writes one channel back into interleaved samples
+func Interleave(samples []float64, channels, channel int, data []float64) {
+	for i, value := range data {
+		samples[i*channels+channel] = value
+	}
+}
@@ -0,0 +1,197 @@
+package detector
+
+import (
+	"math"
+
+	"void-cutter/internal/filter"
+)
+
+// Speech band used for detection energy when SpeechBand is set
+const (
+	speechBandLowHz  = 150.0
+	speechBandHighHz = 4000.0
+)
+
+// TrackActivity holds the per-chunk energy and activity of a single track
+type TrackActivity struct {
+	ChunkFrames int       // Frames per analysis chunk
+	LevelsDB    []float64 // RMS level of each chunk in dBFS
+	// Per-channel RMS level of each chunk in dBFS (nil for mono tracks)
+	ChannelLevelsDB [][]float64
+	Active          []bool  // Whether each chunk is above the silence threshold
+	ThresholdDB     float64 // Threshold used to decide activity
+	NoiseFloor      float64 // Estimated noise floor (NaN unless the threshold follows it)
+	ReferenceLUFS   float64 // Reference loudness of a relative threshold (NaN otherwise)
+	Filename        string
+}
+
+// IsFrameActive reports whether the chunk containing the frame is active
+func (ta *TrackActivity) IsFrameActive(frame int) bool {
+	chunk := frame / ta.ChunkFrames
+	if chunk < 0 || chunk >= len(ta.Active) {
+		return false
+	}
+	return ta.Active[chunk]
+}
+
+// ActiveRatio returns the fraction of chunks that are active
+func (ta *TrackActivity) ActiveRatio() float64 {
+	if len(ta.Active) == 0 {
+		return 0
+	}
+
+	activeChunks := 0
+	for _, active := range ta.Active {
+		if active {
+			activeChunks++
+		}
+	}
+	return float64(activeChunks) / float64(len(ta.Active))
+}
+
+// MeasureLevels splits a track into chunks of config.ChunkSizeMs and returns the
+// chunk size in frames and the RMS level of each chunk in dBFS. With SpeechBand
+// set the levels are measured on a band-passed copy of the track; with
+// PerChannel set each chunk gets the level of its loudest selected channel.
+func MeasureLevels(audioData *AudioData, config Config) (int, []float64) {
+	chunkFrames, levels, _ := MeasureLevelsPerChannel(audioData, config)
+	return chunkFrames, levels
+}
+
+// MeasureLevelsPerChannel is MeasureLevels that also returns the level of each
+// channel per chunk (nil for mono tracks)
+func MeasureLevelsPerChannel(audioData *AudioData, config Config) (int, []float64, [][]float64) {
+	chunkFrames := config.ChunkFrames(audioData.SampleRate)
+	frameCount := audioData.GetFrameCount()
+	audioData = DetectionSignal(audioData, config)
+
+	channelLevels := channelLevelsDB(audioData, chunkFrames)
+	if config.PerChannel && channelLevels != nil {
+		return chunkFrames, loudestChannelLevels(channelLevels, config.DetectionChannels(audioData.Channels)), channelLevels
+	}
+
+	var levels []float64
+	for frameStart := 0; frameStart < frameCount; frameStart += chunkFrames {
+		frameEnd := frameStart + chunkFrames
+		if frameEnd > frameCount {
+			frameEnd = frameCount
+		}
+
+		levels = append(levels, chunkLevelDB(audioData, frameStart, frameEnd))
+	}
+
+	return chunkFrames, levels, channelLevels
+}
+
+// DetectionSignal returns the signal detection levels are measured on: with
+// SpeechBand set a copy of the track limited to the speech band, so that rumble
+// and hiss do not count as activity, otherwise the track itself. The track is
+// not changed.
+func DetectionSignal(audioData *AudioData, config Config) *AudioData {
+	if !config.SpeechBand {
+		return audioData
+	}
+
+	q := math.Sqrt2 / 2
+	chain := []filter.FilterSpec{
+		// Two high-pass sections for a steep cut of HVAC and traffic rumble
+		{Type: filter.TypeHighPass, FrequencyHz: speechBandLowHz, Q: q},
+		{Type: filter.TypeHighPass, FrequencyHz: speechBandLowHz, Q: q},
+	}
+	if speechBandHighHz < float64(audioData.SampleRate)/2 {
+		chain = append(chain, filter.FilterSpec{Type: filter.TypeLowPass, FrequencyHz: speechBandHighHz, Q: q})
+	}
+
+	band := audioData.Clone()
+	samples := band.ToFloat()
+	filter.ApplyToSamples(samples, band.Channels, band.SampleRate, chain)
+	band.FromFloat(samples)
+	return band
+}
+
+// channelLevelsDB measures the RMS level of each chunk per channel in dBFS.
+// Returns nil for mono tracks.
+func channelLevelsDB(audioData *AudioData, chunkFrames int) [][]float64 {
+	channels := audioData.Channels
+	if channels < 2 {
+		return nil
+	}
+
+	frameCount := audioData.GetFrameCount()
+	fullScale := audioData.FullScale()
+	levels := make([][]float64, channels)
+
+	for frameStart := 0; frameStart < frameCount; frameStart += chunkFrames {
+		frameEnd := min(frameStart+chunkFrames, frameCount)
+		for ch := 0; ch < channels; ch++ {
+			var sumSquares float64
+			for frame := frameStart; frame < frameEnd; frame++ {
+				normalized := float64(audioData.Samples[frame*channels+ch]) / fullScale
+				sumSquares += normalized * normalized
+			}
+
+			level := math.Inf(-1)
+			if sumSquares > 0 {
+				level = 10 * math.Log10(sumSquares/float64(frameEnd-frameStart))
+			}
+			levels[ch] = append(levels[ch], level)
+		}
+	}
+
+	return levels
+}
+
+// loudestChannelLevels returns the level of the loudest selected channel in
+// each chunk, so that a chunk is above the threshold when any of them is
+func loudestChannelLevels(channelLevels [][]float64, channels []int) []float64 {
+	levels := make([]float64, len(channelLevels[0]))
+	for chunk := range levels {
+		levels[chunk] = math.Inf(-1)
+		for _, ch := range channels {
+			levels[chunk] = math.Max(levels[chunk], channelLevels[ch][chunk])
+		}
+	}
+	return levels
+}
+
+// chunkLevelDB calculates the RMS level of a chunk of audio in dBFS.
+// Returns -Inf for perfect silence or an empty/out-of-bounds chunk.
+func chunkLevelDB(audioData *AudioData, startFrame, endFrame int) float64 {
+	if startFrame >= endFrame || endFrame > audioData.GetFrameCount() {
+		return math.Inf(-1) // Consider out-of-bounds as silent
+	}
+
+	channels := audioData.Channels
+	startSample := startFrame * channels
+	endSample := endFrame * channels
+
+	if endSample > len(audioData.Samples) {
+		endSample = len(audioData.Samples)
+	}
+
+	// Calculate RMS for this chunk
+	var sumSquares float64
+	sampleCount := endSample - startSample
+
+	// Get appropriate normalization factor based on bit depth
+	normalizationFactor := audioData.FullScale()
+
+	for i := startSample; i < endSample; i++ {
+		// Normalize to [-1, 1] range using appropriate factor
+		normalized := float64(audioData.Samples[i]) / normalizationFactor
+		sumSquares += normalized * normalized
+	}
+
+	if sampleCount == 0 {
+		return math.Inf(-1)
+	}
+
+	rms := math.Sqrt(sumSquares / float64(sampleCount))
+
+	// Convert to dBFS
+	if rms == 0 {
+		return math.Inf(-1) // Perfect silence
+	}
+
+	return 20 * math.Log10(rms)
+}
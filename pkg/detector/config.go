@@ -0,0 +1,89 @@
+package detector
+
+import "fmt"
+
+// Config holds the per-track settings a detector works with
+type Config struct {
+	ThresholdDBFS float64   // Silence threshold in dBFS
+	ChunkSizeMs   int       // Analysis chunk size in milliseconds (default: 10ms)
+	VAD           VADConfig // Settings of the VAD detector
+	SpeechBand    bool      // Measure detection energy within 150-4000 Hz only
+
+	// Per-channel detection: a chunk is active when any of Channels (0-based,
+	// empty for all channels) is active, instead of using the level of the mix
+	PerChannel bool
+	Channels   []int
+
+	// Debouncing: silence is entered at the threshold and broken only above the
+	// threshold plus HysteresisDB by a burst of at least MinBurstMs; activity is
+	// held for HoldMs after the level drops and then falls to the threshold over ReleaseMs
+	HysteresisDB float64
+	MinBurstMs   int
+	HoldMs       int
+	ReleaseMs    int
+
+	// Per-track threshold RelativeDB below the track's loudness ("integrated"
+	// or "speech"), so that detection does not depend on the gain applied before it
+	RelativeTo string
+	RelativeDB float64
+
+	MarginDB float64 // Margin above the noise floor in dB for the adaptive detector
+}
+
+// VADConfig holds parameters for voice activity detection
+type VADConfig struct {
+	BandLowHz      float64 // Speech band used for the energy ratio
+	BandHighHz     float64
+	MinSpeechRatio float64 // Minimum share of the energy in the speech band
+	MaxFlatness    float64 // Maximum spectral flatness (noise is flat, voiced speech is not)
+	MinZCR         float64 // Zero-crossing rate range (crossings per sample)
+	MaxZCR         float64
+	OnsetMs        int // Speech must last this long to start activity
+	HangoverMs     int // Activity continues this long after speech ends
+}
+
+// DefaultVADConfig returns default voice activity detection settings
+func DefaultVADConfig() VADConfig {
+	return VADConfig{
+		BandLowHz:      300.0,
+		BandHighHz:     3400.0,
+		MinSpeechRatio: 0.5,
+		MaxFlatness:    0.4,
+		MinZCR:         0.005,
+		MaxZCR:         0.25,
+		OnsetMs:        30,
+		HangoverMs:     200,
+	}
+}
+
+// ValidateChannels checks that the selected channels exist in a track
+func (c Config) ValidateChannels(channels int) error {
+	for _, channel := range c.Channels {
+		if channel < 0 || channel >= channels {
+			return fmt.Errorf("detection channel %d does not exist (track has %d channels)", channel+1, channels)
+		}
+	}
+	return nil
+}
+
+// DetectionChannels returns the channels that take part in detection
+func (c Config) DetectionChannels(channels int) []int {
+	if c.PerChannel && len(c.Channels) > 0 {
+		return c.Channels
+	}
+
+	all := make([]int, channels)
+	for i := range all {
+		all[i] = i
+	}
+	return all
+}
+
+// ChunkFrames converts the configured chunk size to frames
+func (c Config) ChunkFrames(sampleRate int) int {
+	chunkFrames := (c.ChunkSizeMs * sampleRate) / 1000
+	if chunkFrames == 0 {
+		chunkFrames = 1
+	}
+	return chunkFrames
+}
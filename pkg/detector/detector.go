@@ -0,0 +1,78 @@
+package detector
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"void-cutter/internal/audio"
+)
+
+// AudioData is the decoded track handed to a detector
+type AudioData = audio.AudioData
+
+// Detector decides for each chunk of a track whether it is active.
+// The common silence merge works on the returned activity, so any detector
+// registered with Register can be selected through the detection mode.
+type Detector interface {
+	Detect(audioData *AudioData, config Config) (*TrackActivity, error)
+}
+
+// Func adapts a function to the Detector interface
+type Func func(audioData *AudioData, config Config) (*TrackActivity, error)
+
+// Detect calls f(audioData, config)
+func (f Func) Detect(audioData *AudioData, config Config) (*TrackActivity, error) {
+	return f(audioData, config)
+}
+
+var (
+	detectorsMu sync.RWMutex
+	detectors   = make(map[string]Detector)
+)
+
+// Register makes a detector available under the given name.
+// It panics if the name is empty, already registered, or the detector is nil.
+func Register(name string, d Detector) {
+	detectorsMu.Lock()
+	defer detectorsMu.Unlock()
+
+	if name == "" || d == nil {
+		panic("detector: Register needs a name and a detector")
+	}
+	if _, exists := detectors[name]; exists {
+		panic("detector: Register called twice for " + name)
+	}
+	detectors[name] = d
+}
+
+// Lookup returns the detector registered under name
+func Lookup(name string) (Detector, error) {
+	detectorsMu.RLock()
+	defer detectorsMu.RUnlock()
+
+	d, exists := detectors[name]
+	if !exists {
+		return nil, fmt.Errorf("unknown silence detector %q (available: %s)", name, strings.Join(names(), ", "))
+	}
+	return d, nil
+}
+
+// Names returns the names of all registered detectors in sorted order
+func Names() []string {
+	detectorsMu.RLock()
+	defer detectorsMu.RUnlock()
+
+	return names()
+}
+
+// names lists the registered detectors; the caller holds the lock
+func names() []string {
+	list := make([]string, 0, len(detectors))
+	for name := range detectors {
+		list = append(list, name)
+	}
+	sort.Strings(list)
+	return list
+}